@@ -10,7 +10,7 @@ terraform {
 }
 
 provider "openrouter" {
-  api_key = "your-api-key"
+  api_key = "sk-or-v1-your-api-key"
 }
 
 # Create a test API key
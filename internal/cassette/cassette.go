@@ -0,0 +1,305 @@
+// Package cassette records HTTP interactions with the OpenRouter API to fixture
+// files and replays them in tests.
+//
+// Recorded interactions are scrubbed before they are written: API key values
+// and labels are replaced, 64 character hex hashes are replaced with stable
+// placeholders, and only a few response headers are kept. Request headers,
+// including Authorization, are never recorded.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RecordEnv is the environment variable that switches cassettes from replay
+// to record mode.
+const RecordEnv = "OPENROUTER_RECORD_CASSETTES"
+
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette file without a network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records the scrubbed interactions.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord when RecordEnv is set, ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   Body   `json:"body"`
+}
+
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       Body              `json:"body"`
+}
+
+// Body is stored as JSON when it is valid JSON, for readable fixtures, and as
+// text otherwise.
+type Body struct {
+	JSON json.RawMessage `json:"json,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+func newBody(b []byte) Body {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return Body{}
+	}
+
+	if json.Valid(b) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, b, "", "  "); err == nil {
+			return Body{JSON: indented.Bytes()}
+		}
+	}
+	return Body{Text: string(b)}
+}
+
+func (b Body) bytes() []byte {
+	if b.JSON != nil {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, b.JSON); err == nil {
+			return compacted.Bytes()
+		}
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// recordedHeaders are the only response headers kept in cassettes.
+var recordedHeaders = []string{"Content-Type", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// Recorder is an http.RoundTripper that records or replays a cassette file.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	scrubber     *scrubber
+}
+
+// New returns a recorder of the cassette file at path. In replay mode the file
+// must exist. In record mode requests are sent with transport, which defaults
+// to http.DefaultTransport, and the file is written by Stop.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+		scrubber:  newScrubber(),
+	}
+
+	if mode == ModeReplay {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette, record it by setting %s: %w", RecordEnv, err)
+		}
+		if err := json.Unmarshal(contents, &r.interactions); err != nil {
+			return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	headers := make(map[string]string)
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = r.scrubber.scrub(value)
+		}
+	}
+
+	r.interactions = append(r.interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   r.scrubber.scrub(req.URL.RequestURI()),
+			Body:   newBody([]byte(r.scrubber.scrub(string(body)))),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       newBody([]byte(r.scrubber.scrub(string(respBody)))),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the method, path
+// and body of the request. Requests carry the placeholder hashes of earlier
+// replayed responses, so only key values are scrubbed before matching.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := scrubKeys(req.URL.RequestURI())
+	requestBody := newBody([]byte(scrubKeys(string(body)))).bytes()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.Path != path {
+			continue
+		}
+		if !bytes.Equal(interaction.Request.Body.bytes(), requestBody) {
+			continue
+		}
+
+		r.used[i] = true
+
+		header := make(http.Header)
+		for name, value := range interaction.Response.Headers {
+			header.Set(name, value)
+		}
+
+		respBody := interaction.Response.Body.bytes()
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no unused interaction in cassette %s matches %s %s", r.path, req.Method, path)
+}
+
+// Stop writes the recorded interactions in record mode. In replay mode it
+// reports interactions that were never replayed.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		var unused []string
+		for i, interaction := range r.interactions {
+			if !r.used[i] {
+				unused = append(unused, interaction.Request.Method+" "+interaction.Request.Path)
+			}
+		}
+		if len(unused) > 0 {
+			return fmt.Errorf("cassette %s has unused interactions: %s", r.path, strings.Join(unused, ", "))
+		}
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(contents, '\n'), 0o644)
+}
+
+var (
+	// keyPattern matches API key values and their labels, such as
+	// sk-or-v1-0e6...1c96.
+	keyPattern = regexp.MustCompile(`sk-or-v1-[0-9A-Za-z]+(?:\.\.\.[0-9A-Za-z]+)?`)
+	// hashPattern matches key hashes.
+	hashPattern = regexp.MustCompile(`\b[0-9a-f]{64}\b`)
+)
+
+const scrubbedKey = "sk-or-v1-scrubbed"
+
+func scrubKeys(text string) string {
+	return keyPattern.ReplaceAllString(text, scrubbedKey)
+}
+
+// scrubber replaces secrets in recorded text. Each distinct hash is replaced
+// with the same placeholder throughout the cassette, so that requests made
+// with a hash returned by an earlier response still match on replay.
+type scrubber struct {
+	hashes map[string]string
+}
+
+func newScrubber() *scrubber {
+	return &scrubber{hashes: make(map[string]string)}
+}
+
+func (s *scrubber) scrub(text string) string {
+	text = scrubKeys(text)
+
+	return hashPattern.ReplaceAllStringFunc(text, func(hash string) string {
+		placeholder, ok := s.hashes[hash]
+		if !ok {
+			placeholder = fmt.Sprintf("%064x", len(s.hashes)+1)
+			s.hashes[hash] = placeholder
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,129 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testKey  = "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"
+	testHash = "4b7c1e9f2a6d8c3b5e0f7a1d9c2b6e4f8a3d5c7b9e1f0a2c4d6b8e0f1a3c5d7b"
+)
+
+func TestRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = io.WriteString(w, `{"data":{"hash":"`+testHash+`","label":"sk-or-v1-6f1...d8e"},"key":"`+testKey+`"}`)
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"data":{"hash":"`+strings.TrimPrefix(r.URL.Path, "/keys/")+`"}}`)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	httpClient := &http.Client{Transport: recorder}
+	doRequest(t, httpClient, http.MethodPost, server.URL+"/keys", `{"name":"recorded"}`)
+	doRequest(t, httpClient, http.MethodGet, server.URL+"/keys/"+testHash, "")
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, secret := range []string{testKey, testHash, "6f1...d8e", "session=secret", "Authorization"} {
+		if strings.Contains(string(contents), secret) {
+			t.Errorf("expected %q to be scrubbed from cassette:\n%s", secret, contents)
+		}
+	}
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Replay does not use the network, so the server is no longer needed.
+	server.Close()
+
+	httpClient = &http.Client{Transport: replayer}
+	created := doRequest(t, httpClient, http.MethodPost, "https://openrouter.ai/keys", `{"name":"recorded"}`)
+	if !strings.Contains(created, scrubbedKey) {
+		t.Errorf("expected scrubbed key in replayed response, got %s", created)
+	}
+
+	placeholder := strings.Repeat("0", 63) + "1"
+	fetched := doRequest(t, httpClient, http.MethodGet, "https://openrouter.ai/keys/"+placeholder, "")
+	if !strings.Contains(fetched, placeholder) {
+		t.Errorf("expected placeholder hash in replayed response, got %s", fetched)
+	}
+
+	if err := replayer.Stop(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`[{"request":{"method":"GET","path":"/key","body":{}},"response":{"status_code":200,"body":{"json":{"data":{}}}}}]`), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://openrouter.ai/keys", nil)
+	if _, err := replayer.RoundTrip(req); err == nil {
+		t.Error("expected error for unrecorded request")
+	}
+
+	if err := replayer.Stop(); err == nil {
+		t.Error("expected error for unused interaction")
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil {
+		t.Error("expected error for missing cassette")
+	}
+}
+
+func doRequest(t *testing.T, httpClient *http.Client, method, url, body string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testKey)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return string(contents)
+}
@@ -0,0 +1,19 @@
+package client
+
+import "context"
+
+// API is the set of OpenRouter operations used by the provider. It is
+// implemented by Client against the OpenRouter API and by MemoryClient in
+// memory, so that resources can be tested without a network.
+type API interface {
+	GetCurrentApiKey(ctx context.Context) (*ApiKeyInfo, error)
+	ListApiKeys(ctx context.Context, params *ListApiKeysRequest) ([]ApiKeyInfo, error)
+	ListAllApiKeys(ctx context.Context, params *ListApiKeysRequest) ([]ApiKeyInfo, error)
+	CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	GetApiKey(ctx context.Context, hash string) (*ApiKeyInfo, error)
+	UpdateApiKey(ctx context.Context, hash string, req *UpdateApiKeyRequest) (*ApiKeyInfo, error)
+	DeleteApiKey(ctx context.Context, hash string) error
+}
+
+var _ API = &Client{}
+var _ API = &MemoryClient{}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// keyCache serves GetApiKey lookups from a single snapshot of all keys, taken
+// with ListAllApiKeys the first time a key is looked up. Concurrent lookups
+// share the same snapshot request. Keys written through the client are
+// invalidated and looked up with GetApiKey from then on, as are keys missing
+// from the snapshot.
+type keyCache struct {
+	group singleflight.Group
+
+	mu          sync.Mutex
+	snapshot    map[string]ApiKeyInfo
+	invalidated map[string]bool
+}
+
+// WithKeyCache enables serving GetApiKey from a snapshot of ListApiKeys, so
+// that refreshing many keys costs a few paginated list requests instead of one
+// request per key. The snapshot is kept for the lifetime of the client.
+func WithKeyCache() Option {
+	return func(c *Client) {
+		c.keyCache = &keyCache{
+			invalidated: make(map[string]bool),
+		}
+	}
+}
+
+// get returns the key from the snapshot, loading it with list on first use,
+// and whether it was found. The snapshot is loaded once for all concurrent
+// lookups, so it is not cancelled with the lookup that started it but bounded
+// by timeout instead. Each lookup stops waiting when its own ctx is done.
+func (kc *keyCache) get(ctx context.Context, hash string, timeout time.Duration, list func(context.Context) ([]ApiKeyInfo, error)) (*ApiKeyInfo, bool, error) {
+	kc.mu.Lock()
+	snapshot := kc.snapshot
+	invalidated := kc.invalidated[hash]
+	kc.mu.Unlock()
+
+	if invalidated {
+		return nil, false, nil
+	}
+
+	if snapshot == nil {
+		results := kc.group.DoChan("snapshot", func() (interface{}, error) {
+			kc.mu.Lock()
+			if kc.snapshot != nil {
+				defer kc.mu.Unlock()
+				return kc.snapshot, nil
+			}
+			kc.mu.Unlock()
+
+			loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+			defer cancel()
+
+			apiKeys, err := list(loadCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			loaded := make(map[string]ApiKeyInfo, len(apiKeys))
+			for _, apiKey := range apiKeys {
+				loaded[apiKey.ID] = apiKey
+			}
+
+			tflog.SubsystemDebug(ctx, httpLogSubsystem, "loaded API key cache snapshot", map[string]interface{}{
+				"keys": len(loaded),
+			})
+
+			kc.mu.Lock()
+			kc.snapshot = loaded
+			kc.mu.Unlock()
+
+			return loaded, nil
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case result := <-results:
+			if result.Err != nil {
+				return nil, false, result.Err
+			}
+			snapshot = result.Val.(map[string]ApiKeyInfo)
+		}
+	}
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	// A write may have happened while the snapshot was loading.
+	if kc.invalidated[hash] {
+		return nil, false, nil
+	}
+
+	apiKey, ok := snapshot[hash]
+	if !ok {
+		return nil, false, nil
+	}
+	return &apiKey, true, nil
+}
+
+// invalidate stops serving the key from the snapshot.
+func (kc *keyCache) invalidate(hash string) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	kc.invalidated[hash] = true
+}
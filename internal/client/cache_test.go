@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeyCache(t *testing.T) {
+	var listRequests, getRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/keys":
+			atomic.AddInt32(&listRequests, 1)
+			if r.URL.Query().Get("include_disabled") != "true" {
+				t.Errorf("expected snapshot to include disabled keys")
+			}
+			if r.URL.Query().Get("offset") != "" {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"hash":"abc123","name":"cached"},{"hash":"def456","name":"cached"}]}`))
+		case r.Method == http.MethodGet:
+			atomic.AddInt32(&getRequests, 1)
+			_, _ = w.Write([]byte(`{"data":{"hash":"` + r.URL.Path[len("/keys/"):] + `","name":"fresh"}}`))
+		case r.Method == http.MethodPatch:
+			_, _ = w.Write([]byte(`{"data":{"hash":"abc123","name":"fresh"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithKeyCache())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apiKey, err := c.GetApiKey(ctx, "def456")
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if apiKey.Name != "cached" {
+				t.Errorf("expected key from snapshot, got %q", apiKey.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The snapshot is a first page and an empty second page.
+	if listRequests != 2 || getRequests != 0 {
+		t.Fatalf("expected 2 list and 0 get requests, got %d and %d", listRequests, getRequests)
+	}
+
+	name := "fresh"
+	if _, err := c.UpdateApiKey(ctx, "abc123", &UpdateApiKeyRequest{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	apiKey, err := c.GetApiKey(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if apiKey.Name != "fresh" {
+		t.Errorf("expected updated key to be read from the API, got %q", apiKey.Name)
+	}
+
+	apiKey, err = c.GetApiKey(ctx, "ghi789")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if apiKey.ID != "ghi789" {
+		t.Errorf("expected missing key to be read from the API, got %q", apiKey.ID)
+	}
+
+	if listRequests != 2 || getRequests != 2 {
+		t.Errorf("expected 2 list and 2 get requests, got %d and %d", listRequests, getRequests)
+	}
+}
+
+func TestKeyCacheCancelledLookup(t *testing.T) {
+	listed := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var listRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/keys" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		atomic.AddInt32(&listRequests, 1)
+		if r.URL.Query().Get("offset") != "" {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		listed <- struct{}{}
+		<-release
+		_, _ = w.Write([]byte(`{"data":[{"hash":"abc123","name":"cached"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithKeyCache())
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := c.GetApiKey(cancelledCtx, "abc123")
+		cancelled <- err
+	}()
+	<-listed
+
+	waiting := make(chan error, 1)
+	go func() {
+		apiKey, err := c.GetApiKey(context.Background(), "abc123")
+		if err == nil && apiKey.Name != "cached" {
+			t.Errorf("expected key from snapshot, got %q", apiKey.Name)
+		}
+		waiting <- err
+	}()
+
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled lookup to stop waiting, got %v", err)
+	}
+
+	close(release)
+	if err := <-waiting; err != nil {
+		t.Errorf("expected the snapshot to outlive the cancelled lookup, got %s", err)
+	}
+
+	// The snapshot is a first page and an empty second page.
+	if listRequests != 2 {
+		t.Errorf("expected the snapshot to be loaded once, got %d list requests", listRequests)
+	}
+}
@@ -8,7 +8,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/standujar/terraform-provider-openrouter/internal/telemetry"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -16,31 +25,151 @@ const (
 	defaultTimeout = 30 * time.Second
 )
 
+type APIError struct {
+	StatusCode int
+	Message    string
+	// ProviderName is the upstream provider that returned the error, when it
+	// did not come from OpenRouter itself.
+	ProviderName string
+	// Reasons are the moderation reasons the request was flagged for.
+	Reasons []string
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	if e.ProviderName != "" {
+		message += fmt.Sprintf(" (provider: %s)", e.ProviderName)
+	}
+	if len(e.Reasons) > 0 {
+		message += fmt.Sprintf(" (moderation reasons: %s)", strings.Join(e.Reasons, ", "))
+	}
+	return message
+}
+
+// newAPIError builds the error of a failed request from its response body,
+// which holds either a top-level message or an error envelope such as
+// {"error": {"code": 402, "message": "...", "metadata": {...}}}. It falls back
+// to the whole body when neither has a message.
+func newAPIError(statusCode int, body []byte) *APIError {
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		message := errResp.Message
+		if message == "" {
+			message = errResp.Error.Message
+		}
+		if message != "" {
+			apiErr := &APIError{StatusCode: statusCode, Message: Redact(message)}
+			if metadata := errResp.Error.Metadata; metadata != nil {
+				apiErr.ProviderName = Redact(metadata.ProviderName)
+				for _, reason := range metadata.Reasons {
+					apiErr.Reasons = append(apiErr.Reasons, Redact(reason))
+				}
+			}
+			return apiErr
+		}
+	}
+	return &APIError{StatusCode: statusCode, Message: Redact(string(body))}
+}
+
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL     string
+	credentials CredentialSource
+	httpClient  *http.Client
+	userAgent   string
+	headers     map[string]string
+	rateLimiter *rateLimiter
+	keyCache    *keyCache
+}
+
+type Option func(*Client)
+
+// WithRequestTimeout sets the timeout of each individual HTTP request. The
+// overall duration of an operation is bounded by the caller's context.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
 }
 
-func NewClient(apiKey string, baseURL *string) *Client {
+// WithTransport sets the transport used for all requests, such as one built
+// with NewTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithCredentialSource replaces the static API key passed to NewClient.
+func WithCredentialSource(credentials CredentialSource) Option {
+	return func(c *Client) {
+		c.credentials = credentials
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeaders sets additional headers sent with every request. They cannot
+// override the Authorization, Content-Type and User-Agent headers.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.headers = headers
+	}
+}
+
+func NewClient(apiKey string, baseURL *string, opts ...Option) *Client {
 	url := defaultBaseURL
 	if baseURL != nil && *baseURL != "" {
 		url = *baseURL
 	}
 
-	return &Client{
-		baseURL: url,
-		apiKey:  apiKey,
+	c := &Client{
+		baseURL:     url,
+		credentials: StaticCredentials(apiKey),
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		rateLimiter: newRateLimiter(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
 	}
+	c.httpClient.Transport = otelhttp.NewTransport(transport)
+
+	return c
 }
 
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) (err error) {
+	ctx = newHTTPLogContext(ctx)
+
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "openrouter "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", path),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
@@ -52,31 +181,76 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	apiKey, err := c.credentials.ApiKey(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	release, err := c.rateLimiter.wait(ctx)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
+	defer release()
+
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "sending HTTP request", map[string]interface{}{
+		"method": method,
+		"url":    req.URL.String(),
+		"body":   Redact(string(jsonBody)),
+	})
+
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		err = fmt.Errorf("request failed: %w", redactError(err))
+
+		tflog.SubsystemTrace(ctx, httpLogSubsystem, "HTTP request failed", map[string]interface{}{
+			"method":     method,
+			"url":        req.URL.String(),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"error":      err.Error(),
+		})
+
+		return err
+	}
 	defer resp.Body.Close()
 
+	c.rateLimiter.observe(ctx, resp.StatusCode, resp.Header)
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "received HTTP response", map[string]interface{}{
+		"method":     method,
+		"url":        req.URL.String(),
+		"status":     resp.StatusCode,
+		"latency_ms": time.Since(start).Milliseconds(),
+		"request_id": requestID(resp.Header),
+		"body":       Redact(string(respBody)),
+	})
+
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err != nil {
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
-		}
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, errResp.Message)
+		return newAPIError(resp.StatusCode, respBody)
 	}
 
 	if result != nil && len(respBody) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+			return fmt.Errorf("failed to unmarshal response: %w", redactError(err))
 		}
 	}
 
@@ -116,15 +290,66 @@ func (c *Client) ListApiKeys(ctx context.Context, params *ListApiKeysRequest) ([
 	return resp.Data, nil
 }
 
+// ListAllApiKeys follows the offset pagination of ListApiKeys until a page
+// with no new keys is returned.
+func (c *Client) ListAllApiKeys(ctx context.Context, params *ListApiKeysRequest) ([]ApiKeyInfo, error) {
+	pageParams := ListApiKeysRequest{}
+	if params != nil {
+		pageParams = *params
+	}
+
+	var all []ApiKeyInfo
+	seen := make(map[string]bool)
+	for {
+		page, err := c.ListApiKeys(ctx, &pageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, apiKey := range page {
+			if seen[apiKey.ID] {
+				continue
+			}
+			seen[apiKey.ID] = true
+			all = append(all, apiKey)
+			added++
+		}
+
+		if added == 0 {
+			return all, nil
+		}
+
+		pageParams.Offset += len(page)
+	}
+}
+
 func (c *Client) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
 	var resp CreateApiKeyResponse
 	if err := c.doRequest(ctx, "POST", "/keys", req, &resp); err != nil {
 		return nil, err
 	}
+	if c.keyCache != nil {
+		c.keyCache.invalidate(resp.Data.ID)
+	}
 	return &resp, nil
 }
 
+// GetApiKey returns the key with the given hash, from the snapshot of the key
+// cache when enabled with WithKeyCache.
 func (c *Client) GetApiKey(ctx context.Context, hash string) (*ApiKeyInfo, error) {
+	if c.keyCache != nil {
+		apiKey, ok, err := c.keyCache.get(ctx, hash, c.httpClient.Timeout, func(ctx context.Context) ([]ApiKeyInfo, error) {
+			return c.ListAllApiKeys(ctx, &ListApiKeysRequest{IncludeDisabled: true})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return apiKey, nil
+		}
+	}
+
 	var resp GetApiKeyResponse
 	if err := c.doRequest(ctx, "GET", "/keys/"+hash, nil, &resp); err != nil {
 		return nil, err
@@ -133,6 +358,10 @@ func (c *Client) GetApiKey(ctx context.Context, hash string) (*ApiKeyInfo, error
 }
 
 func (c *Client) UpdateApiKey(ctx context.Context, hash string, req *UpdateApiKeyRequest) (*ApiKeyInfo, error) {
+	if c.keyCache != nil {
+		c.keyCache.invalidate(hash)
+	}
+
 	var resp UpdateApiKeyResponse
 	if err := c.doRequest(ctx, "PATCH", "/keys/"+hash, req, &resp); err != nil {
 		return nil, err
@@ -141,5 +370,9 @@ func (c *Client) UpdateApiKey(ctx context.Context, hash string, req *UpdateApiKe
 }
 
 func (c *Client) DeleteApiKey(ctx context.Context, hash string) error {
+	if c.keyCache != nil {
+		c.keyCache.invalidate(hash)
+	}
+
 	return c.doRequest(ctx, "DELETE", "/keys/"+hash, nil, nil)
-}
\ No newline at end of file
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestClientHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"data":{"hash":"abc123","name":"current"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL,
+		WithUserAgent("terraform-provider-openrouter/1.2.3 terraform/1.9.0"),
+		WithHeaders(map[string]string{
+			"HTTP-Referer":  "https://example.com/infra",
+			"X-Title":       "infra-automation",
+			"Authorization": "Bearer overridden",
+		}),
+	)
+
+	if _, err := c.GetCurrentApiKey(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"Authorization": "Bearer sk-or-v1-test",
+		"Content-Type":  "application/json",
+		"User-Agent":    "terraform-provider-openrouter/1.2.3 terraform/1.9.0",
+		"Http-Referer":  "https://example.com/infra",
+		"X-Title":       "infra-automation",
+	}
+
+	for name, value := range expected {
+		if got.Get(name) != value {
+			t.Errorf("expected %s header %q, got %q", name, value, got.Get(name))
+		}
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	c := NewClient("sk-or-v1-test", &server.URL)
+
+	if _, err := c.GetApiKey(context.Background(), "abc123"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if traceparent == "" {
+		t.Error("expected traceparent header to be propagated")
+	}
+
+	var requestSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "openrouter GET" {
+			requestSpan = span
+		}
+	}
+	if requestSpan == nil {
+		t.Fatalf("expected request span, got %d spans", len(recorder.Ended()))
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range requestSpan.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	if attrs["http.response.status_code"].AsInt64() != http.StatusNotFound {
+		t.Errorf("expected status code attribute 404, got %v", attrs["http.response.status_code"])
+	}
+	if attrs["url.path"].AsString() != "/keys/abc123" {
+		t.Errorf("expected url.path attribute, got %v", attrs["url.path"])
+	}
+	if requestSpan.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %+v", requestSpan.Status())
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		body       string
+		expected   APIError
+	}{
+		"envelope": {
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":{"code":401,"message":"No auth credentials found"}}`,
+			expected:   APIError{StatusCode: http.StatusUnauthorized, Message: "No auth credentials found"},
+		},
+		"envelope with provider": {
+			statusCode: http.StatusBadGateway,
+			body:       `{"error":{"code":502,"message":"Provider returned error","metadata":{"provider_name":"Example","raw":"upstream failure"}}}`,
+			expected:   APIError{StatusCode: http.StatusBadGateway, Message: "Provider returned error", ProviderName: "Example"},
+		},
+		"envelope with moderation": {
+			statusCode: http.StatusForbidden,
+			body:       `{"error":{"code":403,"message":"Input flagged","metadata":{"reasons":["harassment","violence"],"flagged_input":"..."}}}`,
+			expected:   APIError{StatusCode: http.StatusForbidden, Message: "Input flagged", Reasons: []string{"harassment", "violence"}},
+		},
+		"top-level message": {
+			statusCode: http.StatusNotFound,
+			body:       `{"message":"Key not found"}`,
+			expected:   APIError{StatusCode: http.StatusNotFound, Message: "Key not found"},
+		},
+		"string error": {
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":"Unauthorized"}`,
+			expected:   APIError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"},
+		},
+		"not json": {
+			statusCode: http.StatusBadGateway,
+			body:       `<html>502 Bad Gateway</html>`,
+			expected:   APIError{StatusCode: http.StatusBadGateway, Message: "<html>502 Bad Gateway</html>"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := newAPIError(testCase.statusCode, []byte(testCase.body))
+			if !reflect.DeepEqual(*got, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, *got)
+			}
+		})
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusForbidden, Message: "Input flagged", ProviderName: "Example", Reasons: []string{"harassment", "violence"}}
+
+	expected := "API error (status 403): Input flagged (provider: Example) (moderation reasons: harassment, violence)"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestClientRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithRequestTimeout(100*time.Millisecond))
+
+	start := time.Now()
+	if _, err := c.GetApiKey(context.Background(), "abc123"); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the request to be cancelled after its timeout, took %s", elapsed)
+	}
+}
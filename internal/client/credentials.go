@@ -0,0 +1,105 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credentialExpiryWindow is how long before its expiry a credential returned
+// by a credential process is refreshed.
+const credentialExpiryWindow = time.Minute
+
+// CredentialSource provides the API key sent with each request.
+type CredentialSource interface {
+	ApiKey(ctx context.Context) (string, error)
+}
+
+type StaticCredentials string
+
+func (s StaticCredentials) ApiKey(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// ProcessCredentials runs a local command that prints
+// {"api_key": "...", "expires_at": "<RFC3339>"} to stdout, caching the key
+// until it expires. A key without expires_at is cached for the lifetime of
+// the provider.
+type ProcessCredentials struct {
+	command string
+
+	mu        sync.Mutex
+	apiKey    string
+	expiresAt *time.Time
+}
+
+type credentialProcessOutput struct {
+	ApiKey    string     `json:"api_key"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func NewProcessCredentials(command string) *ProcessCredentials {
+	return &ProcessCredentials{command: command}
+}
+
+func (p *ProcessCredentials) ApiKey(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKey != "" && (p.expiresAt == nil || time.Now().Add(credentialExpiryWindow).Before(*p.expiresAt)) {
+		return p.apiKey, nil
+	}
+
+	output, err := p.run(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.apiKey = output.ApiKey
+	p.expiresAt = output.ExpiresAt
+
+	return p.apiKey, nil
+}
+
+func (p *ProcessCredentials) run(ctx context.Context) (*credentialProcessOutput, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("credential process failed: %w: %s", err, Redact(msg))
+		}
+		return nil, fmt.Errorf("credential process failed: %w", err)
+	}
+
+	// The output holds the key, so decoding errors must not include it.
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, errors.New("credential process output is not valid JSON of the form {\"api_key\": \"...\", \"expires_at\": \"...\"}")
+	}
+
+	if output.ApiKey == "" {
+		return nil, errors.New("credential process output has no api_key")
+	}
+
+	if output.ExpiresAt != nil && !time.Now().Before(*output.ExpiresAt) {
+		return nil, fmt.Errorf("credential process returned a key that expired at %s", output.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return &output, nil
+}
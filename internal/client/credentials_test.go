@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// credentialProcessCommand returns a command that records each run in a file
+// and prints output.
+func credentialProcessCommand(t *testing.T, output string) (string, func() int) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use sh")
+	}
+
+	dir := t.TempDir()
+	runs := filepath.Join(dir, "runs")
+	script := filepath.Join(dir, "credentials.sh")
+
+	content := fmt.Sprintf("#!/bin/sh\necho run >> %q\ncat <<'OUTPUT'\n%s\nOUTPUT\n", runs, output)
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatalf("unable to write credential process script: %s", err)
+	}
+
+	countRuns := func() int {
+		content, err := os.ReadFile(runs)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(content), "run")
+	}
+
+	return script, countRuns
+}
+
+func TestProcessCredentialsCachesKey(t *testing.T) {
+	command, runs := credentialProcessCommand(t, `{"api_key": "sk-or-v1-process"}`)
+	credentials := NewProcessCredentials(command)
+
+	for i := 0; i < 3; i++ {
+		apiKey, err := credentials.ApiKey(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if apiKey != "sk-or-v1-process" {
+			t.Fatalf("expected sk-or-v1-process, got %q", apiKey)
+		}
+	}
+
+	if got := runs(); got != 1 {
+		t.Errorf("expected the credential process to run once, ran %d times", got)
+	}
+}
+
+func TestProcessCredentialsRefreshesExpiredKey(t *testing.T) {
+	expiresAt := time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	command, runs := credentialProcessCommand(t, fmt.Sprintf(`{"api_key": "sk-or-v1-process", "expires_at": %q}`, expiresAt))
+	credentials := NewProcessCredentials(command)
+
+	for i := 0; i < 2; i++ {
+		if _, err := credentials.ApiKey(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if got := runs(); got != 2 {
+		t.Errorf("expected a key expiring within the refresh window to be fetched again, ran %d times", got)
+	}
+}
+
+func TestProcessCredentialsErrors(t *testing.T) {
+	testCases := map[string]string{
+		"invalid-json":    `sk-or-v1-secret-value`,
+		"missing-key":     `{"expires_at": "2099-01-01T00:00:00Z"}`,
+		"already-expired": `{"api_key": "sk-or-v1-secret-value", "expires_at": "2000-01-01T00:00:00Z"}`,
+	}
+
+	for name, output := range testCases {
+		t.Run(name, func(t *testing.T) {
+			command, _ := credentialProcessCommand(t, output)
+
+			_, err := NewProcessCredentials(command).ApiKey(context.Background())
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if strings.Contains(err.Error(), "sk-or-v1-secret-value") {
+				t.Errorf("expected error not to contain the key, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestProcessCredentialsCommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use sh")
+	}
+
+	_, err := NewProcessCredentials("echo 'vault is sealed' >&2; exit 3").ApiKey(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("expected error to include stderr, got: %s", err)
+	}
+}
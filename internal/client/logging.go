@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem for HTTP request tracing. Its level
+// is set with TF_LOG_PROVIDER_OPENROUTER_HTTP.
+const httpLogSubsystem = "openrouter_http"
+
+// requestIDHeaders are checked in order for an identifier of the request
+// that can be quoted to OpenRouter support.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Generation-Id",
+	"Cf-Ray",
+}
+
+func newHTTPLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_OPENROUTER_HTTP"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, httpLogSubsystem, secretFieldKeys...)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, httpLogSubsystem, keyFieldPattern, authorizationPattern, apiKeyPattern)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, httpLogSubsystem, keyFieldPattern, authorizationPattern, apiKeyPattern)
+	return ctx
+}
+
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestDoRequestTracesHTTP(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_OPENROUTER_HTTP", "TRACE")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"hash":"abc123","name":"traced"},"key":"` + testSecret + `"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := NewClient(testSecret, &server.URL)
+
+	if _, err := c.CreateApiKey(ctx, &CreateApiKeyRequest{Name: "traced"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	logged := output.String()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %s", err)
+	}
+
+	var response map[string]interface{}
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+httpLogSubsystem {
+			t.Errorf("expected entry in the %s subsystem, got: %v", httpLogSubsystem, entry)
+		}
+		if entry["@message"] == "received HTTP response" {
+			response = entry
+		}
+	}
+
+	if response == nil {
+		t.Fatalf("expected a response trace entry, got: %v", entries)
+	}
+
+	expected := map[string]interface{}{
+		"method":     "POST",
+		"url":        server.URL + "/keys",
+		"status":     float64(http.StatusCreated),
+		"request_id": "req-123",
+	}
+	for key, value := range expected {
+		if response[key] != value {
+			t.Errorf("expected %s %v, got %v", key, value, response[key])
+		}
+	}
+
+	if _, ok := response["latency_ms"]; !ok {
+		t.Error("expected latency_ms to be logged")
+	}
+
+	if !strings.Contains(response["body"].(string), `"hash":"abc123"`) {
+		t.Errorf("expected the response body to be logged, got %v", response["body"])
+	}
+
+	if strings.Contains(logged, testSecret) {
+		t.Errorf("expected log output not to contain the secret, got: %s", logged)
+	}
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MemoryClient is an in-memory implementation of API that follows the
+// behaviour of the OpenRouter keys endpoints: disabled keys are only listed
+// on request, the key value is only returned on creation, and unknown hashes
+// return a 404 APIError.
+type MemoryClient struct {
+	mu    sync.Mutex
+	keys  map[string]ApiKeyInfo
+	order []string
+	now   func() time.Time
+}
+
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		keys: make(map[string]ApiKeyInfo),
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Put stores the key as is, replacing any key with the same hash. It can be
+// used to seed keys or to simulate changes made outside of Terraform.
+func (m *MemoryClient) Put(apiKey ApiKeyInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[apiKey.ID]; !ok {
+		m.order = append(m.order, apiKey.ID)
+	}
+	m.keys[apiKey.ID] = apiKey
+}
+
+// Remove deletes the key with the given hash, if any, as if it had been
+// deleted outside of Terraform.
+func (m *MemoryClient) Remove(hash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(hash)
+}
+
+func (m *MemoryClient) GetCurrentApiKey(ctx context.Context) (*ApiKeyInfo, error) {
+	return &ApiKeyInfo{
+		Name:          "memory",
+		Label:         "sk-or-v1-mem...ory",
+		IsProvisioner: true,
+	}, nil
+}
+
+func (m *MemoryClient) ListApiKeys(ctx context.Context, params *ListApiKeysRequest) ([]ApiKeyInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if params == nil {
+		params = &ListApiKeysRequest{}
+	}
+
+	var matching []ApiKeyInfo
+	for _, hash := range m.order {
+		apiKey := m.keys[hash]
+		if apiKey.IsDisabled && !params.IncludeDisabled {
+			continue
+		}
+		matching = append(matching, withoutKey(apiKey))
+	}
+
+	if params.Offset >= len(matching) {
+		return []ApiKeyInfo{}, nil
+	}
+	matching = matching[params.Offset:]
+
+	if params.Limit > 0 && params.Limit < len(matching) {
+		matching = matching[:params.Limit]
+	}
+
+	return matching, nil
+}
+
+func (m *MemoryClient) ListAllApiKeys(ctx context.Context, params *ListApiKeysRequest) ([]ApiKeyInfo, error) {
+	listParams := ListApiKeysRequest{}
+	if params != nil {
+		listParams = *params
+		listParams.Offset = 0
+		listParams.Limit = 0
+	}
+
+	return m.ListApiKeys(ctx, &listParams)
+}
+
+func (m *MemoryClient) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	hash, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "sk-or-v1-" + secret
+	createdAt := m.now()
+
+	apiKey := ApiKeyInfo{
+		ID:           hash,
+		Key:          key,
+		Label:        key[:12] + "..." + key[len(key)-3:],
+		Name:         req.Name,
+		CreatedAt:    &createdAt,
+		Limit:        req.Limit,
+		LimitMinutes: req.LimitMinutes,
+	}
+
+	m.Put(apiKey)
+
+	return &CreateApiKeyResponse{Data: withoutKey(apiKey), Key: key}, nil
+}
+
+func (m *MemoryClient) GetApiKey(ctx context.Context, hash string) (*ApiKeyInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	apiKey, ok := m.keys[hash]
+	if !ok {
+		return nil, notFoundError()
+	}
+
+	apiKey = withoutKey(apiKey)
+	return &apiKey, nil
+}
+
+func (m *MemoryClient) UpdateApiKey(ctx context.Context, hash string, req *UpdateApiKeyRequest) (*ApiKeyInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	apiKey, ok := m.keys[hash]
+	if !ok {
+		return nil, notFoundError()
+	}
+
+	if req.Name != nil {
+		apiKey.Name = *req.Name
+	}
+	if req.ClearLimit {
+		apiKey.Limit = nil
+	} else if req.Limit != nil {
+		apiKey.Limit = req.Limit
+	}
+	if req.IsDisabled != nil {
+		apiKey.IsDisabled = *req.IsDisabled
+	}
+
+	updatedAt := m.now()
+	apiKey.UpdatedAt = &updatedAt
+	m.keys[hash] = apiKey
+
+	apiKey = withoutKey(apiKey)
+	return &apiKey, nil
+}
+
+func (m *MemoryClient) DeleteApiKey(ctx context.Context, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[hash]; !ok {
+		return notFoundError()
+	}
+
+	m.remove(hash)
+	return nil
+}
+
+func (m *MemoryClient) remove(hash string) {
+	delete(m.keys, hash)
+	for i, orderedHash := range m.order {
+		if orderedHash == hash {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func withoutKey(apiKey ApiKeyInfo) ApiKeyInfo {
+	apiKey.Key = ""
+	return apiKey
+}
+
+func notFoundError() error {
+	return &APIError{StatusCode: http.StatusNotFound, Message: "Key not found"}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMemoryClientListApiKeys(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+
+	var hashes []string
+	for _, name := range []string{"first", "second", "third"} {
+		created, err := m.CreateApiKey(ctx, &CreateApiKeyRequest{Name: name})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if created.Key == "" || created.Data.Key != "" {
+			t.Fatalf("expected key value only at the top level of the create response")
+		}
+		hashes = append(hashes, created.Data.ID)
+	}
+
+	disabled := true
+	if _, err := m.UpdateApiKey(ctx, hashes[1], &UpdateApiKeyRequest{IsDisabled: &disabled}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	enabled, err := m.ListAllApiKeys(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(enabled) != 2 {
+		t.Errorf("expected disabled key to be excluded, got %d keys", len(enabled))
+	}
+
+	page, err := m.ListApiKeys(ctx, &ListApiKeysRequest{IncludeDisabled: true, Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page) != 1 || page[0].ID != hashes[1] {
+		t.Errorf("expected second key on the second page, got %+v", page)
+	}
+
+	if err := m.DeleteApiKey(ctx, hashes[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var apiErr *APIError
+	if _, err := m.GetApiKey(ctx, hashes[0]); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for deleted key, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package client
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ApiKeyInfo struct {
 	ID            string     `json:"hash"`
@@ -10,9 +13,9 @@ type ApiKeyInfo struct {
 	IsProvisioner bool       `json:"is_provisioner,omitempty"`
 	CreatedAt     *time.Time `json:"created_at"`
 	UpdatedAt     *time.Time `json:"updated_at"`
-	Limit         *float64   `json:"limit,omitempty"`
+	Limit         *Money     `json:"limit,omitempty"`
 	LimitMinutes  *int       `json:"limit_minutes,omitempty"`
-	Usage         float64    `json:"usage"`
+	Usage         Money      `json:"usage"`
 	IsDisabled    bool       `json:"disabled"`
 }
 
@@ -27,9 +30,9 @@ type ListApiKeysResponse struct {
 }
 
 type CreateApiKeyRequest struct {
-	Name         string   `json:"name"`
-	Limit        *float64 `json:"limit,omitempty"`
-	LimitMinutes *int     `json:"limit_minutes,omitempty"`
+	Name         string `json:"name"`
+	Limit        *Money `json:"limit,omitempty"`
+	LimitMinutes *int   `json:"limit_minutes,omitempty"`
 }
 
 type CreateApiKeyResponse struct {
@@ -42,12 +45,33 @@ type GetApiKeyResponse struct {
 }
 
 type UpdateApiKeyRequest struct {
-	Name       *string     `json:"name,omitempty"`
-	Limit      *float64    `json:"limit,omitempty"`
+	Name  *string `json:"name,omitempty"`
+	Limit *Money  `json:"limit,omitempty"`
+	// ClearLimit removes the limit of the key by sending an explicit null
+	// limit. An omitted limit leaves it unchanged, and a zero limit allows no
+	// spending at all.
+	ClearLimit bool        `json:"-"`
 	IsDisabled *bool       `json:"disabled,omitempty"`
 	BYOK       *BYOKConfig `json:"byok,omitempty"`
 }
 
+func (r UpdateApiKeyRequest) MarshalJSON() ([]byte, error) {
+	// request has the fields of UpdateApiKeyRequest without this method.
+	type request UpdateApiKeyRequest
+
+	var limit json.RawMessage
+	if r.ClearLimit {
+		limit = json.RawMessage("null")
+	} else if r.Limit != nil {
+		limit = json.RawMessage(r.Limit.String())
+	}
+
+	return json.Marshal(struct {
+		request
+		Limit json.RawMessage `json:"limit,omitempty"`
+	}{request(r), limit})
+}
+
 type BYOKConfig struct {
 	Provider string  `json:"provider"`
 	APIKey   string  `json:"api_key"`
@@ -59,6 +83,34 @@ type UpdateApiKeyResponse struct {
 }
 
 type ErrorResponse struct {
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Message string      `json:"message"`
+	Error   ErrorDetail `json:"error"`
+}
+
+// ErrorDetail is the error of an error response. OpenRouter sends it as an
+// object with a code, a message and metadata, and some endpoints send it as a
+// plain string, which is decoded as the message.
+type ErrorDetail struct {
+	Code     int            `json:"code"`
+	Message  string         `json:"message"`
+	Metadata *ErrorMetadata `json:"metadata,omitempty"`
+}
+
+func (d *ErrorDetail) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*d = ErrorDetail{Message: message}
+		return nil
+	}
+
+	// detail has the fields of ErrorDetail without this method.
+	type detail ErrorDetail
+	return json.Unmarshal(data, (*detail)(d))
+}
+
+// ErrorMetadata describes errors raised by an upstream model provider or by
+// moderation rather than by OpenRouter itself.
+type ErrorMetadata struct {
+	ProviderName string   `json:"provider_name,omitempty"`
+	Reasons      []string `json:"reasons,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var apiKeyInfoSeeds = []string{
+	`{"hash":"abc123","name":"production","label":"sk-or-v1-0e6...1c96","disabled":false,"limit":10,"limit_minutes":60,"usage":1.5,"created_at":"2025-08-24T10:30:00.000Z","updated_at":null}`,
+	`{"hash":"abc123","name":"zero","limit":0,"limit_minutes":0,"usage":0,"created_at":"2025-08-24T10:30:00Z"}`,
+	`{"hash":"abc123","name":"unlimited","limit":null,"limit_minutes":null,"usage":0,"created_at":null}`,
+	`{"hash":"abc123","name":"offset","created_at":"2025-08-24T12:30:00+02:00","updated_at":"2025-08-24T12:30:00.123456789+02:00"}`,
+	`{"hash":"abc123","name":"provisioner","is_provisioner":true,"disabled":true,"limit_remaining":5,"usage_daily":1}`,
+	`{}`,
+	`{"limit":"10"}`,
+	`{"limit":0.1,"usage":0.30000000000000004}`,
+	`{"limit":1e999999999}`,
+	`{"created_at":"yesterday"}`,
+	`[]`,
+}
+
+// FuzzApiKeyInfoDecode checks that any API key the client can decode
+// survives an encode and decode round trip unchanged, including the
+// difference between a null and a zero limit.
+func FuzzApiKeyInfoDecode(f *testing.F) {
+	for _, seed := range apiKeyInfoSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded ApiKeyInfo
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return
+		}
+
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("unable to encode decoded key %+v: %s", decoded, err)
+		}
+
+		var roundTripped ApiKeyInfo
+		if err := json.Unmarshal(encoded, &roundTripped); err != nil {
+			t.Fatalf("unable to decode encoded key %s: %s", encoded, err)
+		}
+
+		if diff := diffApiKeyInfo(decoded, roundTripped); diff != "" {
+			t.Fatalf("round trip of %s through %s changed %s", data, encoded, diff)
+		}
+	})
+}
+
+// FuzzErrorResponseDecode checks that any error response body yields an
+// APIError with the response status, a non-empty message for a non-empty
+// body, and no API keys.
+func FuzzErrorResponseDecode(f *testing.F) {
+	for _, seed := range []string{
+		`{"error":{"code":401,"message":"No auth credentials found"}}`,
+		`{"error":{"code":402,"message":"Insufficient credits","metadata":{"provider_name":null}}}`,
+		`{"error":{"code":403,"message":"Input flagged","metadata":{"reasons":["sk-or-v1-6f1bd2a9c0e84a7bb1d5e3f9a2c47d8e"],"provider_name":"Example"}}}`,
+		`{"message":"Key not found"}`,
+		`{"error":"Unauthorized"}`,
+		`{"message":"invalid key sk-or-v1-6f1bd2a9c0e84a7bb1d5e3f9a2c47d8e"}`,
+		`{"message":"","error":""}`,
+		`<html>502 Bad Gateway</html>`,
+		``,
+	} {
+		f.Add(500, []byte(seed))
+	}
+
+	var statusCode int
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL)
+
+	f.Fuzz(func(t *testing.T, fuzzStatusCode int, fuzzBody []byte) {
+		if fuzzStatusCode < 400 || fuzzStatusCode > 599 {
+			t.Skip()
+		}
+		statusCode, body = fuzzStatusCode, fuzzBody
+
+		_, err := c.GetApiKey(context.Background(), "abc123")
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected an APIError, got %v", err)
+		}
+		if apiErr.StatusCode != statusCode {
+			t.Fatalf("expected status %d, got %d", statusCode, apiErr.StatusCode)
+		}
+		if strings.TrimSpace(string(body)) != "" && apiErr.Message == "" {
+			t.Fatalf("expected a message for body %q", body)
+		}
+		if key := apiKeyPattern.FindString(apiErr.Error()); key != "" {
+			t.Fatalf("expected API keys to be redacted from %q, found %q", apiErr.Error(), key)
+		}
+	})
+}
+
+func diffApiKeyInfo(a, b ApiKeyInfo) string {
+	var diffs []string
+
+	if a.ID != b.ID || a.Key != b.Key || a.Label != b.Label || a.Name != b.Name {
+		diffs = append(diffs, "identity")
+	}
+	if a.IsProvisioner != b.IsProvisioner || a.IsDisabled != b.IsDisabled {
+		diffs = append(diffs, "flags")
+	}
+	if !a.Usage.Equal(b.Usage) {
+		diffs = append(diffs, "usage")
+	}
+	if (a.Limit == nil) != (b.Limit == nil) || (a.Limit != nil && !a.Limit.Equal(*b.Limit)) {
+		diffs = append(diffs, "limit")
+	}
+	if (a.LimitMinutes == nil) != (b.LimitMinutes == nil) || (a.LimitMinutes != nil && *a.LimitMinutes != *b.LimitMinutes) {
+		diffs = append(diffs, "limit_minutes")
+	}
+	if !equalTimes(a.CreatedAt, b.CreatedAt) {
+		diffs = append(diffs, "created_at")
+	}
+	if !equalTimes(a.UpdatedAt, b.UpdatedAt) {
+		diffs = append(diffs, "updated_at")
+	}
+
+	return strings.Join(diffs, ", ")
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
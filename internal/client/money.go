@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is an amount in USD, such as the limit or usage of a key. It keeps the
+// exact decimal value sent by the API, which a float64 cannot always represent.
+//
+// Money is encoded in JSON as a number and can be decoded from a number or a
+// string holding a number.
+type Money struct {
+	value decimal.Decimal
+}
+
+// maxMoneyExponent bounds the decimal exponent of parsed amounts, so that an
+// amount such as 1e999999999 is rejected instead of expanded to a billion
+// digits when it is written back.
+const maxMoneyExponent = 64
+
+// NewMoney parses an amount written in decimal notation, such as "12.50".
+func NewMoney(value string) (Money, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	if exp := d.Exponent(); exp > maxMoneyExponent || exp < -maxMoneyExponent {
+		return Money{}, fmt.Errorf("invalid amount %q: exponent out of range", value)
+	}
+	return Money{value: d}, nil
+}
+
+// MoneyFromFloat returns the amount with the shortest decimal representation
+// of f, so that MoneyFromFloat(0.1) is exactly 0.1.
+func MoneyFromFloat(f float64) Money {
+	return Money{value: decimal.NewFromFloat(f)}
+}
+
+// String returns the amount in decimal notation without trailing zeros.
+func (m Money) String() string {
+	return m.value.String()
+}
+
+// Equal reports whether both amounts have the same value, regardless of how
+// they are written: 10, 10.0 and 10.00 are equal.
+func (m Money) Equal(other Money) bool {
+	return m.value.Equal(other.value)
+}
+
+// Round returns the amount rounded to the given number of decimal places.
+func (m Money) Round(places int32) Money {
+	return Money{value: m.value.Round(places)}
+}
+
+// Float64 returns the nearest float64 to the amount.
+func (m Money) Float64() float64 {
+	f, _ := m.value.Float64()
+	return f
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.value.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var text string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	} else {
+		text = string(data)
+	}
+
+	parsed, err := NewMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
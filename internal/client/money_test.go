@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		json        string
+		expected    string
+		expectError bool
+	}{
+		"number": {
+			json:     `12.5`,
+			expected: "12.5",
+		},
+		"string": {
+			json:     `"12.50"`,
+			expected: "12.5",
+		},
+		"float precision": {
+			json:     `0.1`,
+			expected: "0.1",
+		},
+		"many digits": {
+			json:     `0.30000000000000004`,
+			expected: "0.30000000000000004",
+		},
+		"exponent": {
+			json:     `1e2`,
+			expected: "100",
+		},
+		"exponent out of range": {
+			json:        `1e999999999`,
+			expectError: true,
+		},
+		"invalid string": {
+			json:        `"ten"`,
+			expectError: true,
+		},
+		"bool": {
+			json:        `true`,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(testCase.json), &m)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %s", m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if m.String() != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, m)
+			}
+		})
+	}
+}
+
+func TestMoneyEqual(t *testing.T) {
+	ten, err := NewMoney("10.00")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !ten.Equal(MoneyFromFloat(10)) {
+		t.Errorf("expected 10.00 to equal 10")
+	}
+	sum := 0.1
+	sum += 0.2
+	if MoneyFromFloat(0.3).Equal(MoneyFromFloat(sum)) {
+		t.Errorf("expected 0.3 not to equal %s", MoneyFromFloat(sum))
+	}
+}
+
+func TestUpdateApiKeyRequestMarshalJSON(t *testing.T) {
+	name := "renamed"
+	disabled := true
+	limit := MoneyFromFloat(0.1)
+	zero := MoneyFromFloat(0)
+
+	testCases := map[string]struct {
+		request  UpdateApiKeyRequest
+		expected string
+	}{
+		"empty": {
+			request:  UpdateApiKeyRequest{},
+			expected: `{}`,
+		},
+		"limit": {
+			request:  UpdateApiKeyRequest{Limit: &limit},
+			expected: `{"limit":0.1}`,
+		},
+		"zero limit": {
+			request:  UpdateApiKeyRequest{Limit: &zero},
+			expected: `{"limit":0}`,
+		},
+		"clear limit": {
+			request:  UpdateApiKeyRequest{ClearLimit: true},
+			expected: `{"limit":null}`,
+		},
+		"clear limit wins": {
+			request:  UpdateApiKeyRequest{Limit: &limit, ClearLimit: true},
+			expected: `{"limit":null}`,
+		},
+		"all fields": {
+			request:  UpdateApiKeyRequest{Name: &name, Limit: &limit, IsDisabled: &disabled},
+			expected: `{"name":"renamed","disabled":true,"limit":0.1}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(&testCase.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(body) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiter throttles the requests of a client, which is shared by all
+// resources and data sources of a provider configuration. Besides the
+// configured rate and concurrency, it pauses all requests once the
+// X-RateLimit-* or Retry-After response headers report the limit of the API
+// as exhausted.
+type rateLimiter struct {
+	limiter *rate.Limiter
+	slots   chan struct{}
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// maxRateLimitPause bounds how long a reported rate limit reset pauses
+// requests, so that a bogus or far-future reset cannot block every request
+// until its context times out.
+const maxRateLimitPause = 5 * time.Minute
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests per second,
+// allowing bursts of up to one second worth of requests.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) {
+		burst := int(math.Max(1, math.Ceil(requestsPerSecond)))
+		c.rateLimiter.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests of the client in
+// flight at the same time.
+func WithMaxConcurrentRequests(maxConcurrentRequests int) Option {
+	return func(c *Client) {
+		c.rateLimiter.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+}
+
+// wait blocks until a request may be sent and returns the function releasing
+// its concurrency slot once the response has been read.
+func (l *rateLimiter) wait(ctx context.Context) (func(), error) {
+	l.mu.Lock()
+	pause := l.pausedUntil.Sub(l.now())
+	l.mu.Unlock()
+
+	if pause > 0 {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "waiting for the OpenRouter rate limit to reset", map[string]interface{}{
+			"wait_ms": pause.Milliseconds(),
+		})
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	}
+}
+
+// observe pauses further requests until the rate limit reported by the
+// response headers resets, or for at most maxRateLimitPause.
+func (l *rateLimiter) observe(ctx context.Context, statusCode int, header http.Header) {
+	var until time.Time
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		until = l.parseReset(header.Get("X-RateLimit-Reset"))
+	}
+
+	if statusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+			if retryAt := l.now().Add(time.Duration(seconds) * time.Second); retryAt.After(until) {
+				until = retryAt
+			}
+		}
+	}
+
+	if maxUntil := l.now().Add(maxRateLimitPause); until.After(maxUntil) {
+		tflog.SubsystemWarn(ctx, httpLogSubsystem, "limiting the OpenRouter rate limit pause", map[string]interface{}{
+			"reset":        until.UTC().Format(time.RFC3339),
+			"max_pause_ms": maxRateLimitPause.Milliseconds(),
+		})
+		until = maxUntil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// parseReset parses an X-RateLimit-Reset value, which OpenRouter sends as a
+// Unix timestamp in milliseconds. Unix timestamps in seconds and a number of
+// seconds until the reset are accepted as well.
+func (l *rateLimiter) parseReset(value string) time.Time {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}
+	}
+
+	switch {
+	case reset > 1e12:
+		return time.UnixMilli(reset)
+	case reset > 1e9:
+		return time.Unix(reset, 0)
+	default:
+		return l.now().Add(time.Duration(reset) * time.Second)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data":{"hash":"abc123"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetApiKey(context.Background(), "abc123"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimiterRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"hash":"abc123"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithRateLimit(20))
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, err := c.GetApiKey(context.Background(), "abc123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first 20 requests are a burst, the remaining 10 take 50ms each.
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		statusCode int
+		header     http.Header
+		expected   time.Time
+	}{
+		"remaining": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Minute).UnixMilli(), 10)}},
+		},
+		"exhausted milliseconds": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Minute).UnixMilli(), 10)}},
+			expected:   now.Add(time.Minute),
+		},
+		"exhausted seconds": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Minute).Unix(), 10)}},
+			expected:   now.Add(time.Minute),
+		},
+		"exhausted delta": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"10"}},
+			expected:   now.Add(10 * time.Second),
+		},
+		"retry after": {
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": {"30"}},
+			expected:   now.Add(30 * time.Second),
+		},
+		"far future reset": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(24*time.Hour).UnixMilli(), 10)}},
+			expected:   now.Add(maxRateLimitPause),
+		},
+		"long retry after": {
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": {"86400"}},
+			expected:   now.Add(maxRateLimitPause),
+		},
+		"invalid reset": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"soon"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			l := newRateLimiter()
+			l.now = func() time.Time { return now }
+
+			l.observe(context.Background(), testCase.statusCode, testCase.header)
+
+			if !l.pausedUntil.Equal(testCase.expected) {
+				t.Errorf("expected pause until %s, got %s", testCase.expected, l.pausedUntil)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := newRateLimiter()
+	l.pausedUntil = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.wait(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***"
+
+// secretFieldKeys are the log field keys whose values are always masked.
+var secretFieldKeys = []string{
+	"api_key",
+	"key",
+	"client_key",
+	"authorization",
+	"Authorization",
+}
+
+var (
+	// apiKeyPattern matches OpenRouter API keys such as sk-or-v1-abc123.
+	apiKeyPattern = regexp.MustCompile(`sk-or-[A-Za-z0-9][A-Za-z0-9_-]*`)
+
+	// authorizationPattern matches the credentials of an Authorization header.
+	authorizationPattern = regexp.MustCompile(`(?i)(authorization"?\s*[:=]\s*"?\s*(?:bearer\s+)?)[^\s",}]+`)
+
+	// keyFieldPattern matches the value of "key" and "api_key" JSON fields.
+	keyFieldPattern = regexp.MustCompile(`("(?:api_)?key"\s*:\s*")(?:[^"\\]|\\.)*(")`)
+)
+
+// Redact masks API keys, Authorization header credentials and key JSON fields
+// in s. It is applied to every error message built from API responses.
+func Redact(s string) string {
+	s = keyFieldPattern.ReplaceAllString(s, "${1}"+redacted+"${2}")
+	s = authorizationPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = apiKeyPattern.ReplaceAllString(s, redacted)
+	return s
+}
+
+// redactedError redacts the message of an error while keeping it available
+// to errors.Is and errors.As.
+type redactedError struct {
+	err error
+}
+
+func redactError(err error) error {
+	return &redactedError{err: err}
+}
+
+func (e *redactedError) Error() string {
+	return Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// MaskLogSecrets configures the tflog root logger of ctx to mask the same
+// secrets as Redact, in both messages and fields. Entry points such as CRUD
+// methods call it before logging anything.
+func MaskLogSecrets(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, secretFieldKeys...)
+	ctx = tflog.MaskMessageRegexes(ctx, keyFieldPattern, authorizationPattern, apiKeyPattern)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, keyFieldPattern, authorizationPattern, apiKeyPattern)
+	return ctx
+}
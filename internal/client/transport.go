@@ -0,0 +1,71 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type TransportConfig struct {
+	// ProxyURL overrides the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables when set.
+	ProxyURL string
+
+	// CACertPEM is added to the system certificate pool.
+	CACertPEM []byte
+
+	ClientCertPEM      []byte
+	ClientKeyPEM       []byte
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns a copy of http.DefaultTransport configured with the
+// proxy and TLS settings of cfg.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: scheme and host are required", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if len(cfg.CACertPEM) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, errors.New("no valid PEM certificates found in CA certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0 {
+		if len(cfg.ClientCertPEM) == 0 || len(cfg.ClientKeyPEM) == 0 {
+			return nil, errors.New("both a client certificate and a client key are required")
+		}
+		clientCert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTransportCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"hash":"abc123","name":"current"}}`))
+	}))
+	defer server.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	transport, err := NewTransport(TransportConfig{CACertPEM: caCert})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	c := NewClient("sk-or-v1-test", &server.URL, WithTransport(transport))
+
+	apiKey, err := c.GetCurrentApiKey(context.Background())
+	if err != nil {
+		t.Fatalf("expected request trusting the CA certificate to succeed, got error: %s", err)
+	}
+
+	if apiKey.ID != "abc123" {
+		t.Errorf("expected hash abc123, got %q", apiKey.ID)
+	}
+
+	untrusted := NewClient("sk-or-v1-test", &server.URL)
+
+	if _, err := untrusted.GetCurrentApiKey(context.Background()); err == nil {
+		t.Error("expected request without the CA certificate to fail")
+	}
+}
+
+func TestNewTransportErrors(t *testing.T) {
+	testCases := map[string]TransportConfig{
+		"invalid-proxy-url": {
+			ProxyURL: "proxy.example.com:3128",
+		},
+		"invalid-ca-cert": {
+			CACertPEM: []byte("not a certificate"),
+		},
+		"client-cert-without-key": {
+			ClientCertPEM: []byte("-----BEGIN CERTIFICATE-----"),
+		},
+		"invalid-client-cert": {
+			ClientCertPEM: []byte("not a certificate"),
+			ClientKeyPEM:  []byte("not a key"),
+		},
+	}
+
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewTransport(cfg); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestNewTransportProxyURL(t *testing.T) {
+	transport, err := NewTransport(TransportConfig{ProxyURL: "http://proxy.example.com:3128"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://openrouter.ai/api/v1/keys", nil)
+
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if proxyURL == nil || proxyURL.String() != "http://proxy.example.com:3128" {
+		t.Errorf("expected proxy http://proxy.example.com:3128, got %v", proxyURL)
+	}
+}
@@ -0,0 +1,23 @@
+// Command fakeopenrouter runs the fake OpenRouter API server until
+// interrupted, for trying the provider locally without an OpenRouter account.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/standujar/terraform-provider-openrouter/internal/fakeopenrouter"
+)
+
+func main() {
+	server := fakeopenrouter.NewServer()
+	defer server.Close()
+
+	fmt.Printf("Fake OpenRouter API listening, configure the provider with:\n\n")
+	fmt.Printf("provider \"openrouter\" {\n  api_key  = %q\n  endpoint = %q\n}\n", fakeopenrouter.ProvisioningKey, server.URL+"/api/v1")
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
@@ -0,0 +1,161 @@
+package fakeopenrouter
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault changes how the server responds to matching requests.
+type Fault struct {
+	// Method and PathPrefix select the requests the fault applies to. Empty
+	// values match every request.
+	Method     string
+	PathPrefix string
+
+	// Times is the number of matching requests the fault applies to. Zero
+	// applies it until ClearFaults is called.
+	Times int
+
+	// Latency delays the response.
+	Latency time.Duration
+
+	// StatusCode, when set, replaces the response with an error response of
+	// that status and Header.
+	StatusCode int
+	Header     http.Header
+
+	// MalformedJSON replaces the response with a truncated JSON body.
+	MalformedJSON bool
+
+	// Stall handles the request and then delays its response, as a response
+	// lost after the server acted on the request would.
+	Stall time.Duration
+
+	remaining int
+}
+
+// Latency returns a fault delaying every response by latency.
+func Latency(latency time.Duration) Fault {
+	return Fault{Latency: latency}
+}
+
+// RateLimited returns a fault answering once with a 429 response asking to
+// retry after retryAfter.
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{
+		Times:      1,
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":           {strconv.Itoa(int(retryAfter.Seconds()))},
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(retryAfter).UnixMilli(), 10)},
+		},
+	}
+}
+
+// Stalled returns a fault handling one request and then delaying its response
+// by stall.
+func Stalled(stall time.Duration) Fault {
+	return Fault{Times: 1, Stall: stall}
+}
+
+// ServerError returns a fault answering once with a 500 response.
+func ServerError() Fault {
+	return Fault{Times: 1, StatusCode: http.StatusInternalServerError}
+}
+
+// MalformedJSON returns a fault answering once with a truncated JSON body.
+func MalformedJSON() Fault {
+	return Fault{Times: 1, MalformedJSON: true}
+}
+
+// InjectFault adds a fault. When several faults match a request, the one
+// injected first applies.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fault.remaining = fault.Times
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching the request, consuming one of
+// its applications. It must be called with s.mu held.
+func (s *Server) takeFault(method, path string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, fault.PathPrefix) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.remaining--
+			if fault.remaining == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+// apply delays the request and writes the faulty response, reporting whether
+// the response was written.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return true
+		case <-timer.C:
+		}
+	}
+
+	for name, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	switch {
+	case f.StatusCode != 0:
+		writeError(w, f.StatusCode, http.StatusText(f.StatusCode))
+		return true
+	case f.MalformedJSON:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"hash": "trunc`))
+		return true
+	}
+
+	return false
+}
+
+// stall waits before the recorded response is written, reporting whether the
+// client is still waiting for it.
+func (f *Fault) stall(r *http.Request) bool {
+	timer := time.NewTimer(f.Stall)
+	defer timer.Stop()
+
+	select {
+	case <-r.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,581 @@
+// Package fakeopenrouter provides a stateful, in-process fake of the
+// OpenRouter API for unit tests, acceptance tests and local development.
+//
+// It implements the /key, /keys, /credits and /models endpoints, both at the
+// root and under /api/v1, and requires the provisioning key as a bearer token
+// for key management. Faults such as latency, rate limiting, server errors,
+// stalled and malformed responses can be injected per request.
+package fakeopenrouter
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProvisioningKey is the provisioning key accepted by a server unless another
+// one is set with WithProvisioningKey.
+const ProvisioningKey = "sk-or-v1-fake-provisioning-key"
+
+const defaultPageSize = 100
+
+// Key is an API key stored by the server.
+type Key struct {
+	Hash         string
+	Key          string
+	Name         string
+	Disabled     bool
+	Limit        *float64
+	LimitMinutes *int
+	Usage        float64
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+// Model is a model listed by the /models endpoint.
+type Model struct {
+	ID              string
+	Name            string
+	ContextLength   int
+	PromptPrice     string
+	CompletionPrice string
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+// Server is a fake OpenRouter API server. It must be closed after use.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	provisioningKey string
+	pageSize        int
+	keys            map[string]*Key
+	order           []string
+	totalCredits    float64
+	models          []Model
+	faults          []*Fault
+	requests        []Request
+	now             func() time.Time
+}
+
+type Option func(*Server)
+
+// WithProvisioningKey sets the provisioning key the server accepts.
+func WithProvisioningKey(key string) Option {
+	return func(s *Server) {
+		s.provisioningKey = key
+	}
+}
+
+// WithPageSize sets the number of keys returned by each /keys page.
+func WithPageSize(pageSize int) Option {
+	return func(s *Server) {
+		s.pageSize = pageSize
+	}
+}
+
+// WithModels replaces the models listed by the /models endpoint.
+func WithModels(models ...Model) Option {
+	return func(s *Server) {
+		s.models = models
+	}
+}
+
+// WithClock sets the function used for creation and update timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a fake OpenRouter API server. API clients should use URL
+// as their endpoint.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		provisioningKey: ProvisioningKey,
+		pageSize:        defaultPageSize,
+		keys:            make(map[string]*Key),
+		totalCredits:    100,
+		models: []Model{
+			{ID: "openai/gpt-4o", Name: "OpenAI: GPT-4o", ContextLength: 128000, PromptPrice: "0.0000025", CompletionPrice: "0.00001"},
+			{ID: "anthropic/claude-3.5-sonnet", Name: "Anthropic: Claude 3.5 Sonnet", ContextLength: 200000, PromptPrice: "0.000003", CompletionPrice: "0.000015"},
+			{ID: "meta-llama/llama-3.1-8b-instruct:free", Name: "Meta: Llama 3.1 8B Instruct (free)", ContextLength: 131072, PromptPrice: "0", CompletionPrice: "0"},
+		},
+		now: func() time.Time { return time.Now().UTC() },
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// AddKey stores a key as if it had been created outside of Terraform and
+// returns it with its hash, key value and creation time filled in when unset.
+func (s *Server) AddKey(key Key) Key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key.Hash == "" {
+		key.Hash = randomHex(32)
+	}
+	if key.Key == "" {
+		key.Key = "sk-or-v1-" + randomHex(32)
+	}
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = s.now()
+	}
+
+	if _, ok := s.keys[key.Hash]; !ok {
+		s.order = append(s.order, key.Hash)
+	}
+	s.keys[key.Hash] = &key
+
+	return key
+}
+
+// UpdateKey changes a stored key in place, as if it had been changed outside
+// of Terraform. It reports whether the key exists.
+func (s *Server) UpdateKey(hash string, update func(*Key)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[hash]
+	if ok {
+		update(key)
+	}
+	return ok
+}
+
+// Key returns a copy of the stored key with the given hash.
+func (s *Server) Key(hash string) (Key, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[hash]
+	if !ok {
+		return Key{}, false
+	}
+	return *key, true
+}
+
+// Keys returns copies of all stored keys in creation order.
+func (s *Server) Keys() []Key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]Key, 0, len(s.order))
+	for _, hash := range s.order {
+		keys = append(keys, *s.keys[hash])
+	}
+	return keys
+}
+
+// RemoveKey deletes a stored key, as if it had been deleted outside of
+// Terraform.
+func (s *Server) RemoveKey(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeKey(hash)
+}
+
+// SetTotalCredits sets the credits reported by the /credits endpoint.
+func (s *Server) SetTotalCredits(totalCredits float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.totalCredits = totalCredits
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.RawQuery})
+	fault := s.takeFault(r.Method, path)
+	s.mu.Unlock()
+
+	if fault != nil && fault.apply(w, r) {
+		return
+	}
+
+	if fault != nil && fault.Stall > 0 {
+		recorder := httptest.NewRecorder()
+		s.route(recorder, r, path)
+
+		if fault.stall(r) {
+			for name, values := range recorder.Header() {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(recorder.Code)
+			_, _ = w.Write(recorder.Body.Bytes())
+		}
+		return
+	}
+
+	s.route(w, r, path)
+}
+
+// route serves the request for path, relative to /api/v1.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, path string) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	switch {
+	case path == "/key" && r.Method == http.MethodGet:
+		s.getCurrentKey(w, token)
+	case path == "/credits" && r.Method == http.MethodGet:
+		s.getCredits(w)
+	case path == "/models" && r.Method == http.MethodGet:
+		s.listModels(w)
+	case path == "/keys" || strings.HasPrefix(path, "/keys/"):
+		if token != s.provisioningKey {
+			writeError(w, http.StatusUnauthorized, "Only provisioning keys can manage API keys")
+			return
+		}
+		s.serveKeys(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/keys"), "/"))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Not found: %s %s", r.Method, path))
+	}
+}
+
+func (s *Server) serveKeys(w http.ResponseWriter, r *http.Request, hash string) {
+	switch {
+	case hash == "" && r.Method == http.MethodGet:
+		s.listKeys(w, r)
+	case hash == "" && r.Method == http.MethodPost:
+		s.createKey(w, r)
+	case hash != "" && r.Method == http.MethodGet:
+		s.getKey(w, hash)
+	case hash != "" && r.Method == http.MethodPatch:
+		s.updateKey(w, r, hash)
+	case hash != "" && r.Method == http.MethodDelete:
+		s.deleteKey(w, hash)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+func (s *Server) getCurrentKey(w http.ResponseWriter, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token == s.provisioningKey {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"label":          label(token),
+				"name":           "Provisioning key",
+				"usage":          0,
+				"limit":          nil,
+				"is_free_tier":   false,
+				"is_provisioner": true,
+			},
+		})
+		return
+	}
+
+	for _, key := range s.keys {
+		if key.Key == token && !key.Disabled {
+			data := keyJSON(key)
+			data["is_free_tier"] = false
+			data["is_provisioner"] = false
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+			return
+		}
+	}
+
+	writeError(w, http.StatusUnauthorized, "No auth credentials found")
+}
+
+func (s *Server) getCredits(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var totalUsage float64
+	for _, key := range s.keys {
+		totalUsage += key.Usage
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"total_credits": s.totalCredits,
+			"total_usage":   totalUsage,
+		},
+	})
+}
+
+func (s *Server) listModels(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	models := make([]map[string]interface{}, 0, len(s.models))
+	for _, model := range s.models {
+		models = append(models, map[string]interface{}{
+			"id":             model.ID,
+			"name":           model.Name,
+			"context_length": model.ContextLength,
+			"pricing": map[string]string{
+				"prompt":     model.PromptPrice,
+				"completion": model.CompletionPrice,
+			},
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": models})
+}
+
+func (s *Server) listKeys(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	offset, err := parseNonNegative(query.Get("offset"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid offset")
+		return
+	}
+
+	pageSize, err := parseNonNegative(query.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pageSize == 0 || pageSize > s.pageSize {
+		pageSize = s.pageSize
+	}
+
+	includeDisabled := query.Get("include_disabled") == "true"
+
+	data := []map[string]interface{}{}
+	skipped := 0
+	for _, hash := range s.order {
+		key := s.keys[hash]
+		if key.Disabled && !includeDisabled {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		if len(data) == pageSize {
+			break
+		}
+		data = append(data, keyJSON(key))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (s *Server) createKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name         string   `json:"name"`
+		Limit        *float64 `json:"limit"`
+		LimitMinutes *int     `json:"limit_minutes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	if req.Limit != nil && *req.Limit < 0 {
+		writeError(w, http.StatusBadRequest, "Limit must be a non-negative number")
+		return
+	}
+
+	key := s.AddKey(Key{
+		Name:         req.Name,
+		Limit:        req.Limit,
+		LimitMinutes: req.LimitMinutes,
+	})
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": keyJSON(&key),
+		"key":  key.Key,
+	})
+}
+
+func (s *Server) getKey(w http.ResponseWriter, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[hash]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": keyJSON(key)})
+}
+
+// updateKey only changes the fields present in the body. An explicit null
+// limit removes the limit.
+func (s *Server) updateKey(w http.ResponseWriter, r *http.Request, hash string) {
+	var req map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	var name *string
+	var disabled *bool
+	var limit *float64
+
+	if raw, ok := req["name"]; ok {
+		if err := json.Unmarshal(raw, &name); err != nil || name == nil || *name == "" {
+			writeError(w, http.StatusBadRequest, "Name must be a non-empty string")
+			return
+		}
+	}
+	if raw, ok := req["disabled"]; ok {
+		if err := json.Unmarshal(raw, &disabled); err != nil || disabled == nil {
+			writeError(w, http.StatusBadRequest, "Disabled must be a boolean")
+			return
+		}
+	}
+	_, limitSet := req["limit"]
+	if limitSet {
+		if err := json.Unmarshal(req["limit"], &limit); err != nil || (limit != nil && *limit < 0) {
+			writeError(w, http.StatusBadRequest, "Limit must be a non-negative number or null")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[hash]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Key not found")
+		return
+	}
+
+	if name != nil {
+		key.Name = *name
+	}
+	if disabled != nil {
+		key.Disabled = *disabled
+	}
+	if limitSet {
+		key.Limit = limit
+	}
+
+	updatedAt := s.now()
+	key.UpdatedAt = &updatedAt
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": keyJSON(key)})
+}
+
+func (s *Server) deleteKey(w http.ResponseWriter, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[hash]; !ok {
+		writeError(w, http.StatusNotFound, "Key not found")
+		return
+	}
+
+	s.removeKey(hash)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": true})
+}
+
+func (s *Server) removeKey(hash string) {
+	delete(s.keys, hash)
+	for i, orderedHash := range s.order {
+		if orderedHash == hash {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func keyJSON(key *Key) map[string]interface{} {
+	data := map[string]interface{}{
+		"hash":          key.Hash,
+		"name":          key.Name,
+		"label":         label(key.Key),
+		"disabled":      key.Disabled,
+		"limit":         key.Limit,
+		"limit_minutes": key.LimitMinutes,
+		"usage":         key.Usage,
+		"created_at":    key.CreatedAt.Format(time.RFC3339),
+		"updated_at":    nil,
+	}
+	if key.UpdatedAt != nil {
+		data["updated_at"] = key.UpdatedAt.Format(time.RFC3339)
+	}
+	return data
+}
+
+func label(key string) string {
+	if len(key) < 15 {
+		return key
+	}
+	return key[:12] + "..." + key[len(key)-3:]
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in the envelope used by the OpenRouter API.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    statusCode,
+			"message": message,
+		},
+	})
+}
+
+func parseNonNegative(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return n, nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
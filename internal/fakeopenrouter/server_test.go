@@ -0,0 +1,238 @@
+package fakeopenrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+func newTestClient(s *Server) *client.Client {
+	return client.NewClient(ProvisioningKey, &s.URL)
+}
+
+func TestServerKeyLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ctx := context.Background()
+	c := newTestClient(s)
+
+	limit := client.MoneyFromFloat(10)
+	created, err := c.CreateApiKey(ctx, &client.CreateApiKeyRequest{Name: "lifecycle", Limit: &limit})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(created.Key, "sk-or-v1-") || created.Data.ID == "" {
+		t.Fatalf("unexpected create response: %+v", created)
+	}
+
+	name := "renamed"
+	disabled := true
+	updated, err := c.UpdateApiKey(ctx, created.Data.ID, &client.UpdateApiKeyRequest{Name: &name, IsDisabled: &disabled})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updated.Name != "renamed" || !updated.IsDisabled || updated.Limit == nil || !updated.Limit.Equal(limit) {
+		t.Errorf("expected only name and disabled to change, got %+v", updated)
+	}
+
+	if err := c.DeleteApiKey(ctx, created.Data.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var apiErr *client.APIError
+	if _, err := c.GetApiKey(ctx, created.Data.ID); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for deleted key, got %v", err)
+	}
+}
+
+func TestServerPatchNullLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	limit := 5.0
+	key := s.AddKey(Key{Name: "limited", Limit: &limit})
+
+	req, err := http.NewRequest(http.MethodPatch, s.URL+"/api/v1/keys/"+key.Hash, strings.NewReader(`{"limit": null}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+ProvisioningKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	stored, _ := s.Key(key.Hash)
+	if stored.Limit != nil {
+		t.Errorf("expected explicit null to clear the limit, got %v", *stored.Limit)
+	}
+}
+
+func TestServerListPagination(t *testing.T) {
+	s := NewServer(WithPageSize(2))
+	defer s.Close()
+
+	for i := 0; i < 5; i++ {
+		s.AddKey(Key{Name: "paged", Disabled: i == 4})
+	}
+
+	c := newTestClient(s)
+
+	page, err := c.ListApiKeys(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page) != 2 {
+		t.Errorf("expected a page of 2 keys, got %d", len(page))
+	}
+
+	enabled, err := c.ListAllApiKeys(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(enabled) != 4 {
+		t.Errorf("expected 4 enabled keys, got %d", len(enabled))
+	}
+
+	all, err := c.ListAllApiKeys(context.Background(), &client.ListApiKeysRequest{IncludeDisabled: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected 5 keys including disabled, got %d", len(all))
+	}
+}
+
+func TestServerAuthorization(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	key := s.AddKey(Key{Name: "regular"})
+	c := client.NewClient(key.Key, &s.URL)
+
+	current, err := c.GetCurrentApiKey(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if current.IsProvisioner {
+		t.Errorf("expected a regular key not to be a provisioning key")
+	}
+
+	var apiErr *client.APIError
+	if _, err := c.ListApiKeys(context.Background(), nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 when listing keys with a regular key, got %v", err)
+	}
+}
+
+func TestServerCreditsAndModels(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.SetTotalCredits(50)
+	s.AddKey(Key{Name: "used", Usage: 12.5})
+
+	var credits struct {
+		Data struct {
+			TotalCredits float64 `json:"total_credits"`
+			TotalUsage   float64 `json:"total_usage"`
+		} `json:"data"`
+	}
+	getJSON(t, s.URL+"/api/v1/credits", &credits)
+	if credits.Data.TotalCredits != 50 || credits.Data.TotalUsage != 12.5 {
+		t.Errorf("unexpected credits %+v", credits.Data)
+	}
+
+	var models struct {
+		Data []struct {
+			ID      string            `json:"id"`
+			Pricing map[string]string `json:"pricing"`
+		} `json:"data"`
+	}
+	getJSON(t, s.URL+"/api/v1/models", &models)
+	if len(models.Data) == 0 || models.Data[0].Pricing["prompt"] == "" {
+		t.Errorf("unexpected models %+v", models.Data)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ctx := context.Background()
+	c := newTestClient(s)
+	key := s.AddKey(Key{Name: "faulty"})
+
+	var apiErr *client.APIError
+
+	s.InjectFault(ServerError())
+	if _, err := c.GetApiKey(ctx, key.Hash); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %v", err)
+	}
+
+	s.InjectFault(Fault{Method: http.MethodGet, PathPrefix: "/keys/", Times: 1, StatusCode: http.StatusTooManyRequests})
+	if _, err := c.GetApiKey(ctx, key.Hash); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %v", err)
+	}
+
+	s.InjectFault(MalformedJSON())
+	if _, err := c.GetApiKey(ctx, key.Hash); err == nil || !strings.Contains(err.Error(), "unmarshal") {
+		t.Errorf("expected unmarshal error, got %v", err)
+	}
+
+	if _, err := c.GetApiKey(ctx, key.Hash); err != nil {
+		t.Errorf("expected faults to be consumed, got %v", err)
+	}
+
+	s.InjectFault(Latency(50 * time.Millisecond))
+	start := time.Now()
+	if _, err := c.GetApiKey(ctx, key.Hash); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected latency to be injected, took %s", elapsed)
+	}
+	s.ClearFaults()
+
+	s.InjectFault(Stalled(10 * time.Second))
+	stallCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := c.DeleteApiKey(stallCtx, key.Hash); err == nil {
+		t.Fatal("expected the stalled request to time out")
+	}
+	if _, ok := s.Key(key.Hash); ok {
+		t.Errorf("expected the stalled request to be handled")
+	}
+}
+
+func getJSON(t *testing.T, url string, result interface{}) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+ProvisioningKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -3,33 +3,39 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/standujar/terraform-provider-openrouter/internal/client"
+	"github.com/standujar/terraform-provider-openrouter/internal/telemetry"
 )
 
 var _ datasource.DataSource = &ApiKeyDataSource{}
 
+const defaultDataSourceReadTimeout = 5 * time.Minute
+
 func NewApiKeyDataSource() datasource.DataSource {
 	return &ApiKeyDataSource{}
 }
 
 type ApiKeyDataSource struct {
-	client *client.Client
+	client client.API
 }
 
 type ApiKeyDataSourceModel struct {
-	ID             types.String  `tfsdk:"id"`
-	Name           types.String  `tfsdk:"name"`
-	IsProvisioner  types.Bool    `tfsdk:"is_provisioner"`
-	Limit          types.Float64 `tfsdk:"limit"`
-	LimitMinutes   types.Int64   `tfsdk:"limit_minutes"`
-	Usage          types.Float64 `tfsdk:"usage"`
-	IsDisabled     types.Bool    `tfsdk:"is_disabled"`
-	CreatedAt      types.String  `tfsdk:"created_at"`
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	IsProvisioner types.Bool     `tfsdk:"is_provisioner"`
+	Limit         MoneyValue     `tfsdk:"limit"`
+	LimitMinutes  types.Int64    `tfsdk:"limit_minutes"`
+	Usage         MoneyValue     `tfsdk:"usage"`
+	IsDisabled    types.Bool     `tfsdk:"is_disabled"`
+	CreatedAt     types.String   `tfsdk:"created_at"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *ApiKeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -53,16 +59,18 @@ func (d *ApiKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "Whether the API key is a provisioner key.",
 				Computed:            true,
 			},
-			"limit": schema.Float64Attribute{
+			"limit": schema.NumberAttribute{
 				MarkdownDescription: "The spend limit for the API key in USD.",
+				CustomType:          MoneyType{},
 				Computed:            true,
 			},
 			"limit_minutes": schema.Int64Attribute{
 				MarkdownDescription: "The time limit for the API key in minutes.",
 				Computed:            true,
 			},
-			"usage": schema.Float64Attribute{
+			"usage": schema.NumberAttribute{
 				MarkdownDescription: "The current usage of the API key in USD.",
+				CustomType:          MoneyType{},
 				Computed:            true,
 			},
 			"is_disabled": schema.BoolAttribute{
@@ -74,6 +82,10 @@ func (d *ApiKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
@@ -82,12 +94,12 @@ func (d *ApiKeyDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -97,6 +109,11 @@ func (d *ApiKeyDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 func (d *ApiKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "data.openrouter_api_key", "read")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeyDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -105,26 +122,32 @@ func (d *ApiKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultDataSourceReadTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Trace(ctx, "reading API key data source", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
 
 	apiKey, err := d.client.GetApiKey(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to read API key", err)
 		return
 	}
 
 	data.Name = types.StringValue(apiKey.Name)
 	data.IsProvisioner = types.BoolValue(apiKey.IsProvisioner)
-	data.Usage = types.Float64Value(apiKey.Usage)
+	data.Usage = NewMoneyValue(apiKey.Usage)
 	data.IsDisabled = types.BoolValue(apiKey.IsDisabled)
-	
-	if apiKey.Limit != nil {
-		data.Limit = types.Float64Value(*apiKey.Limit)
-	} else {
-		data.Limit = types.Float64Null()
-	}
+
+	data.Limit = NewMoneyPointerValue(apiKey.Limit)
 
 	if apiKey.LimitMinutes != nil {
 		data.LimitMinutes = types.Int64Value(int64(*apiKey.LimitMinutes))
@@ -137,4 +160,4 @@ func (d *ApiKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
\ No newline at end of file
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApiKeyDataSource(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiKeyDestroyed(backend),
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(testAccApiKeyResourceConfig(name, 10, false) + `
+data "openrouter_api_key" "test" {
+  id = openrouter_api_key.test.id
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.openrouter_api_key.test", "id", "openrouter_api_key.test", "id"),
+					resource.TestCheckResourceAttr("data.openrouter_api_key.test", "name", name),
+					resource.TestCheckResourceAttr("data.openrouter_api_key.test", "limit", "10"),
+					resource.TestCheckResourceAttr("data.openrouter_api_key.test", "is_disabled", "false"),
+					resource.TestCheckResourceAttrSet("data.openrouter_api_key.test", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiKeyDataSource_notFound(t *testing.T) {
+	backend := newTestAccBackend(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(fmt.Sprintf(`
+data "openrouter_api_key" "test" {
+  id = %q
+}
+`, "0000000000000000000000000000000000000000000000000000000000000000")),
+				ExpectError: regexp.MustCompile(`Unable to read API key`),
+			},
+		},
+	})
+}
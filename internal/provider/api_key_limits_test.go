@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+// apiKeyLimits are the limits of a key as returned by the API, where nil
+// means no limit and zero is a real limit.
+type apiKeyLimits struct {
+	Limit        *client.Money
+	LimitMinutes *int
+}
+
+// Generate picks nil and zero as often as other values, as they are the
+// cases that must not be confused.
+func (apiKeyLimits) Generate(r *rand.Rand, size int) reflect.Value {
+	var limits apiKeyLimits
+
+	switch r.Intn(3) {
+	case 1:
+		limit := client.MoneyFromFloat(0)
+		limits.Limit = &limit
+	case 2:
+		limit := client.MoneyFromFloat(float64(r.Intn(100000)) / 100)
+		limits.Limit = &limit
+	}
+
+	switch r.Intn(3) {
+	case 1:
+		limitMinutes := 0
+		limits.LimitMinutes = &limitMinutes
+	case 2:
+		limitMinutes := r.Intn(525600)
+		limits.LimitMinutes = &limitMinutes
+	}
+
+	return reflect.ValueOf(limits)
+}
+
+// apiKeyFromJSON stores a key with the given limits in a memory client, after
+// a JSON round trip through the client model as if returned by the API.
+func apiKeyFromJSON(t *testing.T, limits apiKeyLimits) *client.MemoryClient {
+	t.Helper()
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	encoded, err := json.Marshal(client.ApiKeyInfo{
+		ID:           "abc123",
+		Name:         "limits",
+		Limit:        limits.Limit,
+		LimitMinutes: limits.LimitMinutes,
+		CreatedAt:    &createdAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded client.ApiKeyInfo
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	api := client.NewMemoryClient()
+	api.Put(decoded)
+	return api
+}
+
+func limitsMatch(limits apiKeyLimits, limitValue MoneyValue, limitMinutes types.Int64) bool {
+	limit, diags := limitValue.ValueMoney()
+	if diags.HasError() {
+		return false
+	}
+	if (limits.Limit == nil) != (limit == nil) || (limits.Limit != nil && !limits.Limit.Equal(*limit)) {
+		return false
+	}
+	if (limits.LimitMinutes == nil) != limitMinutes.IsNull() || (limits.LimitMinutes != nil && int64(*limits.LimitMinutes) != limitMinutes.ValueInt64()) {
+		return false
+	}
+	return true
+}
+
+func nullDataSourceTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"read": types.StringType,
+		}),
+	}
+}
+
+func TestApiKeyResourceReadLimits(t *testing.T) {
+	ctx := context.Background()
+
+	property := func(limits apiKeyLimits) bool {
+		r, schemaResp := newTestApiKeyResource(t, apiKeyFromJSON(t, limits))
+
+		// The prior state has the opposite nullness, so that Read must set both.
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		prior := ApiKeyResourceModel{
+			ID:                  types.StringValue("abc123"),
+			Key:                 types.StringNull(),
+			Name:                types.StringValue("limits"),
+			Limit:               NewMoneyValue(client.MoneyFromFloat(99)),
+			LimitMinutes:        types.Int64Value(99),
+			IsDisabled:          types.BoolValue(false),
+			Usage:               NewMoneyValue(client.MoneyFromFloat(0)),
+			CreatedAt:           timetypes.NewRFC3339Null(),
+			DeletionProtection:  types.BoolValue(false),
+			OnDestroy:           types.StringValue(onDestroyDelete),
+			AdoptExistingByName: types.BoolValue(false),
+			Timeouts:            nullApiKeyTimeouts(),
+		}
+		if limits.Limit != nil {
+			prior.Limit = NewMoneyNull()
+		}
+		if limits.LimitMinutes != nil {
+			prior.LimitMinutes = types.Int64Null()
+		}
+		if diags := state.Set(ctx, &prior); diags.HasError() {
+			t.Fatalf("unexpected state diagnostics: %v", diags)
+		}
+
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected read diagnostics: %v", resp.Diagnostics)
+		}
+
+		var read ApiKeyResourceModel
+		if diags := resp.State.Get(ctx, &read); diags.HasError() {
+			t.Fatalf("unexpected state diagnostics: %v", diags)
+		}
+
+		return limitsMatch(limits, read.Limit, read.LimitMinutes)
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApiKeyDataSourceReadLimits(t *testing.T) {
+	ctx := context.Background()
+
+	property := func(limits apiKeyLimits) bool {
+		d := &ApiKeyDataSource{}
+		configureResp := &datasource.ConfigureResponse{}
+		d.Configure(ctx, datasource.ConfigureRequest{ProviderData: apiKeyFromJSON(t, limits)}, configureResp)
+
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		if diags := config.Set(ctx, &ApiKeyDataSourceModel{
+			ID:            types.StringValue("abc123"),
+			Name:          types.StringNull(),
+			IsProvisioner: types.BoolNull(),
+			Limit:         NewMoneyNull(),
+			LimitMinutes:  types.Int64Null(),
+			Usage:         NewMoneyNull(),
+			IsDisabled:    types.BoolNull(),
+			CreatedAt:     types.StringNull(),
+			Timeouts:      nullDataSourceTimeouts(),
+		}); diags.HasError() {
+			t.Fatalf("unexpected config diagnostics: %v", diags)
+		}
+
+		resp := &datasource.ReadResponse{State: config}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected read diagnostics: %v", resp.Diagnostics)
+		}
+
+		var read ApiKeyDataSourceModel
+		if diags := resp.State.Get(ctx, &read); diags.HasError() {
+			t.Fatalf("unexpected state diagnostics: %v", diags)
+		}
+
+		return limitsMatch(limits, read.Limit, read.LimitMinutes)
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApiKeysDataSourceReadLimits(t *testing.T) {
+	ctx := context.Background()
+
+	property := func(limits apiKeyLimits) bool {
+		d := &ApiKeysDataSource{}
+		configureResp := &datasource.ConfigureResponse{}
+		d.Configure(ctx, datasource.ConfigureRequest{ProviderData: apiKeyFromJSON(t, limits)}, configureResp)
+
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		if diags := config.Set(ctx, &ApiKeysDataSourceModel{
+			IncludeDisabled: types.BoolNull(),
+			Keys:            nil,
+			Timeouts:        nullDataSourceTimeouts(),
+		}); diags.HasError() {
+			t.Fatalf("unexpected config diagnostics: %v", diags)
+		}
+
+		resp := &datasource.ReadResponse{State: config}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected read diagnostics: %v", resp.Diagnostics)
+		}
+
+		var read ApiKeysDataSourceModel
+		if diags := resp.State.Get(ctx, &read); diags.HasError() {
+			t.Fatalf("unexpected state diagnostics: %v", diags)
+		}
+
+		return len(read.Keys) == 1 && limitsMatch(limits, read.Keys[0].Limit, read.Keys[0].LimitMinutes)
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
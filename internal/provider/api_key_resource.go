@@ -2,40 +2,78 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
-
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/standujar/terraform-provider-openrouter/internal/client"
+	"github.com/standujar/terraform-provider-openrouter/internal/telemetry"
 )
 
 var _ resource.Resource = &ApiKeyResource{}
 var _ resource.ResourceWithImportState = &ApiKeyResource{}
+var _ resource.ResourceWithUpgradeState = &ApiKeyResource{}
+var _ resource.ResourceWithMoveState = &ApiKeyResource{}
+
+// apiKeyResourceSchemaVersion must be incremented, and a state upgrader from
+// the previous version added, whenever the schema changes in a way that is
+// not compatible with existing state.
+const apiKeyResourceSchemaVersion = 1
+
+const (
+	onDestroyDelete  = "delete"
+	onDestroyDisable = "disable"
+	onDestroyAbandon = "abandon"
+)
+
+// apiKeyClockSkew is how much earlier than the start of a failed create call,
+// by the local clock, a key may have been created and still be adopted.
+const apiKeyClockSkew = 30 * time.Second
+
+// apiKeyReconcileTimeout bounds the lookup of a key after a failed create
+// call, which runs even when the create timeout has expired.
+const apiKeyReconcileTimeout = 30 * time.Second
+
+const defaultApiKeyTimeout = 5 * time.Minute
 
 func NewApiKeyResource() resource.Resource {
-	return &ApiKeyResource{}
+	return &ApiKeyResource{now: time.Now}
 }
 
 type ApiKeyResource struct {
-	client *client.Client
+	client client.API
+	now    func() time.Time
 }
 
 type ApiKeyResourceModel struct {
-	ID           types.String  `tfsdk:"id"`
-	Key          types.String  `tfsdk:"key"`
-	Name         types.String  `tfsdk:"name"`
-	Limit        types.Float64 `tfsdk:"limit"`
-	LimitMinutes types.Int64   `tfsdk:"limit_minutes"`
-	IsDisabled   types.Bool    `tfsdk:"is_disabled"`
-	Usage        types.Float64 `tfsdk:"usage"`
-	CreatedAt    types.String  `tfsdk:"created_at"`
+	ID                  types.String      `tfsdk:"id"`
+	Key                 types.String      `tfsdk:"key"`
+	Name                types.String      `tfsdk:"name"`
+	Limit               MoneyValue        `tfsdk:"limit"`
+	LimitMinutes        types.Int64       `tfsdk:"limit_minutes"`
+	IsDisabled          types.Bool        `tfsdk:"is_disabled"`
+	Usage               MoneyValue        `tfsdk:"usage"`
+	CreatedAt           timetypes.RFC3339 `tfsdk:"created_at"`
+	DeletionProtection  types.Bool        `tfsdk:"deletion_protection"`
+	OnDestroy           types.String      `tfsdk:"on_destroy"`
+	AdoptExistingByName types.Bool        `tfsdk:"adopt_existing_by_name"`
+	Timeouts            timeouts.Value    `tfsdk:"timeouts"`
 }
 
 func (r *ApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,6 +83,7 @@ func (r *ApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *ApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an OpenRouter API key.",
+		Version:             apiKeyResourceSchemaVersion,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -66,8 +105,9 @@ func (r *ApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The name of the API key.",
 				Required:            true,
 			},
-			"limit": schema.Float64Attribute{
-				MarkdownDescription: "The spend limit for the API key in USD.",
+			"limit": schema.NumberAttribute{
+				MarkdownDescription: "The spend limit for the API key in USD. Removing it makes the key unlimited, while `0` allows no spending.",
+				CustomType:          MoneyType{},
 				Optional:            true,
 			},
 			"limit_minutes": schema.Int64Attribute{
@@ -80,15 +120,62 @@ func (r *ApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
-			"usage": schema.Float64Attribute{
+			"usage": schema.NumberAttribute{
 				MarkdownDescription: "The current usage of the API key in USD.",
+				CustomType:          MoneyType{},
 				Computed:            true,
 			},
 			"created_at": schema.StringAttribute{
-				MarkdownDescription: "The creation timestamp of the API key.",
+				MarkdownDescription: "The creation timestamp of the API key in RFC3339 format.",
+				CustomType:          timetypes.RFC3339Type{},
 				Computed:            true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from destroying the API key. Must be set to `false` and applied before the key can be destroyed. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the API key when the resource is destroyed: `delete` deletes the key, `disable` disables it and `abandon` leaves it untouched. " +
+					"In every case the key is removed from the Terraform state. Defaults to `delete`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyDelete, onDestroyDisable, onDestroyAbandon),
+				},
+			},
+			"adopt_existing_by_name": schema.BoolAttribute{
+				MarkdownDescription: "Whether to adopt an existing API key with exactly the same name instead of creating a new one. " +
+					"Creation fails if more than one key has that name. The `key` value of an adopted key is not available. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// nullApiKeyTimeouts returns the value of an absent timeouts block, for
+// states that are built without a configuration.
+func nullApiKeyTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
 	}
 }
 
@@ -97,12 +184,12 @@ func (r *ApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -112,6 +199,11 @@ func (r *ApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "openrouter_api_key", "create")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -120,35 +212,103 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultApiKeyTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Keys that already have the planned name are never adopted after a
+	// failed create call, as they belong to someone else.
+	var existingIDs map[string]bool
+
+	existing, err := r.findApiKeysByName(ctx, data.Name.ValueString(), time.Time{})
+	if err != nil {
+		if data.AdoptExistingByName.ValueBool() {
+			addClientError(&resp.Diagnostics, "Unable to list API keys to adopt", err)
+			return
+		}
+
+		tflog.Warn(ctx, "unable to list API keys before creation, a failed creation will not be reconciled", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		existingIDs = make(map[string]bool, len(existing))
+		for _, apiKey := range existing {
+			existingIDs[apiKey.ID] = true
+		}
+	}
+
+	if data.AdoptExistingByName.ValueBool() {
+		if len(existing) > 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Ambiguous API Key Name",
+				fmt.Sprintf("Found %d API keys named %q, unable to choose one to adopt. Rename the duplicates or import the intended key by its hash.", len(existing), data.Name.ValueString()),
+			)
+			return
+		}
+
+		if len(existing) == 1 {
+			resp.Diagnostics.AddWarning(
+				"Existing API Key Adopted",
+				fmt.Sprintf("Adopted the existing API key %q (%s) instead of creating a new one because adopt_existing_by_name is set. "+
+					"The key value is only returned when a key is created, so the key attribute is null.", existing[0].Name, existing[0].ID),
+			)
+
+			r.adoptApiKey(ctx, &data, existing[0], &resp.Diagnostics)
+
+			// The key is saved even if it could not be updated, in which case
+			// the error taints it.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "creating API key")
 
 	createReq := &client.CreateApiKeyRequest{
 		Name: data.Name.ValueString(),
 	}
 
-	if !data.Limit.IsNull() {
-		limit := data.Limit.ValueFloat64()
-		createReq.Limit = &limit
+	limit, diags := data.Limit.ValueMoney()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	createReq.Limit = limit
 
 	if !data.LimitMinutes.IsNull() {
 		limitMinutes := int(data.LimitMinutes.ValueInt64())
 		createReq.LimitMinutes = &limitMinutes
 	}
 
+	start := r.now()
+
 	apiKey, err := r.client.CreateApiKey(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key, got error: %s", err))
+		if r.reconcileFailedCreate(ctx, &data, err, start, existingIDs, &resp.Diagnostics) {
+			// The key is saved even if it could not be updated, in which case
+			// the error taints it.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+
+		addClientError(&resp.Diagnostics, "Unable to create API key", err)
 		return
 	}
 
 	data.ID = types.StringValue(apiKey.Data.ID)
 	data.Key = types.StringValue(apiKey.Key)
-	data.Usage = types.Float64Value(apiKey.Data.Usage)
+	data.Usage = NewMoneyValue(apiKey.Data.Usage)
 	data.IsDisabled = types.BoolValue(apiKey.Data.IsDisabled)
-	
+
 	if apiKey.Data.CreatedAt != nil {
-		data.CreatedAt = types.StringValue(apiKey.Data.CreatedAt.Format("2006-01-02T15:04:05Z"))
+		data.CreatedAt = timetypes.NewRFC3339TimeValue(apiKey.Data.CreatedAt.UTC())
 	}
 
 	tflog.Trace(ctx, "created API key")
@@ -156,7 +316,152 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// reconcileFailedCreate looks for a key the failed create call may still have
+// created, such as when the response timed out, and adopts it if exactly one
+// key with the planned name was created since the call started and is not one
+// of existingIDs, the keys listed before it. Nothing is adopted when
+// existingIDs is nil, as the keys could not be listed. It reports whether a
+// key was adopted.
+func (r *ApiKeyResource) reconcileFailedCreate(ctx context.Context, data *ApiKeyResourceModel, createErr error, start time.Time, existingIDs map[string]bool, diags *diag.Diagnostics) bool {
+	var apiErr *client.APIError
+	if errors.As(createErr, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError &&
+		apiErr.StatusCode != http.StatusRequestTimeout && apiErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	if existingIDs == nil {
+		return false
+	}
+
+	tflog.Debug(ctx, "reconciling failed API key creation", map[string]interface{}{
+		"name":  data.Name.ValueString(),
+		"error": createErr.Error(),
+	})
+
+	// The create call may have failed because its timeout expired, so the
+	// lookup gets a context of its own.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), apiKeyReconcileTimeout)
+	defer cancel()
+
+	matches, err := r.findApiKeysByName(ctx, data.Name.ValueString(), start.Add(-apiKeyClockSkew))
+	if err != nil {
+		tflog.Warn(ctx, "unable to list API keys while reconciling failed creation", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	var candidates []client.ApiKeyInfo
+	for _, match := range matches {
+		if !existingIDs[match.ID] {
+			candidates = append(candidates, match)
+		}
+	}
+
+	if len(candidates) != 1 {
+		return false
+	}
+
+	diags.AddWarning(
+		"Created API Key Adopted After Error",
+		fmt.Sprintf("The create call failed with: %s. OpenRouter created the API key %q (%s) regardless, so it was adopted. "+
+			"The key value is only returned by a successful create call, so the key attribute is null.", createErr, candidates[0].Name, candidates[0].ID),
+	)
+
+	r.adoptApiKey(ctx, data, candidates[0], diags)
+
+	return true
+}
+
+// findApiKeysByName returns the keys, including disabled ones, whose name
+// exactly matches name and, if createdAfter is set, were created after it.
+func (r *ApiKeyResource) findApiKeysByName(ctx context.Context, name string, createdAfter time.Time) ([]client.ApiKeyInfo, error) {
+	apiKeys, err := r.client.ListAllApiKeys(ctx, &client.ListApiKeysRequest{
+		IncludeDisabled: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []client.ApiKeyInfo
+	for _, apiKey := range apiKeys {
+		if apiKey.Name != name {
+			continue
+		}
+
+		if !createdAfter.IsZero() && (apiKey.CreatedAt == nil || apiKey.CreatedAt.Before(createdAfter)) {
+			continue
+		}
+
+		matches = append(matches, apiKey)
+	}
+
+	return matches, nil
+}
+
+// adoptApiKey fills data from an existing key and updates the key to match
+// the planned limit, clearing it when none is planned, and disabled status.
+// data identifies the key even when the update fails.
+func (r *ApiKeyResource) adoptApiKey(ctx context.Context, data *ApiKeyResourceModel, apiKey client.ApiKeyInfo, diags *diag.Diagnostics) {
+	tflog.Info(ctx, "adopting existing API key", map[string]interface{}{
+		"id":   apiKey.ID,
+		"name": apiKey.Name,
+	})
+
+	data.ID = types.StringValue(apiKey.ID)
+	data.Key = types.StringNull()
+	data.Usage = NewMoneyValue(apiKey.Usage)
+	data.CreatedAt = timetypes.NewRFC3339Null()
+
+	if apiKey.CreatedAt != nil {
+		data.CreatedAt = timetypes.NewRFC3339TimeValue(apiKey.CreatedAt.UTC())
+	}
+
+	updateReq := &client.UpdateApiKeyRequest{}
+
+	limit, limitDiags := data.Limit.ValueMoney()
+	diags.Append(limitDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	if limit == nil && apiKey.Limit != nil {
+		updateReq.ClearLimit = true
+	} else if limit != nil && (apiKey.Limit == nil || !apiKey.Limit.Equal(*limit)) {
+		updateReq.Limit = limit
+	}
+
+	if data.IsDisabled.ValueBool() != apiKey.IsDisabled {
+		isDisabled := data.IsDisabled.ValueBool()
+		updateReq.IsDisabled = &isDisabled
+	}
+
+	if updateReq.Limit != nil || updateReq.ClearLimit || updateReq.IsDisabled != nil {
+		updated, err := r.client.UpdateApiKey(ctx, apiKey.ID, updateReq)
+		if err != nil {
+			addClientError(diags, fmt.Sprintf("Unable to update adopted API key %s", apiKey.ID), err)
+			return
+		}
+		apiKey = *updated
+		data.Usage = NewMoneyValue(apiKey.Usage)
+	}
+
+	if !data.LimitMinutes.IsNull() && (apiKey.LimitMinutes == nil || int64(*apiKey.LimitMinutes) != data.LimitMinutes.ValueInt64()) {
+		diags.AddAttributeWarning(
+			path.Root("limit_minutes"),
+			"Adopted API Key Limit Differs",
+			fmt.Sprintf("The adopted API key %s has a different limit_minutes than configured and it cannot be changed in place. "+
+				"The next plan will show the difference.", apiKey.ID),
+		)
+	}
+}
+
 func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "openrouter_api_key", "read")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -165,29 +470,35 @@ func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultApiKeyTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Trace(ctx, "reading API key", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
 
 	apiKey, err := r.client.GetApiKey(ctx, data.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to read API key", err)
 		return
 	}
 
 	data.Name = types.StringValue(apiKey.Name)
-	data.Usage = types.Float64Value(apiKey.Usage)
+	data.Usage = NewMoneyValue(apiKey.Usage)
 	data.IsDisabled = types.BoolValue(apiKey.IsDisabled)
-	
-	if apiKey.Limit != nil {
-		data.Limit = types.Float64Value(*apiKey.Limit)
-	} else {
-		data.Limit = types.Float64Null()
-	}
+
+	data.Limit = NewMoneyPointerValue(apiKey.Limit)
 
 	if apiKey.LimitMinutes != nil {
 		data.LimitMinutes = types.Int64Value(int64(*apiKey.LimitMinutes))
@@ -196,13 +507,18 @@ func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	if apiKey.CreatedAt != nil {
-		data.CreatedAt = types.StringValue(apiKey.CreatedAt.Format("2006-01-02T15:04:05Z"))
+		data.CreatedAt = timetypes.NewRFC3339TimeValue(apiKey.CreatedAt.UTC())
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "openrouter_api_key", "update")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -211,6 +527,16 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultApiKeyTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	tflog.Trace(ctx, "updating API key", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
@@ -229,13 +555,16 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if !data.Limit.Equal(state.Limit) {
-		if !data.Limit.IsNull() {
-			limit := data.Limit.ValueFloat64()
-			updateReq.Limit = &limit
-		} else {
-			var zeroLimit float64 = 0
-			updateReq.Limit = &zeroLimit
+		limit, diags := data.Limit.ValueMoney()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+
+		// A zero limit allows no spending, so a removed limit is cleared
+		// with an explicit null instead.
+		updateReq.Limit = limit
+		updateReq.ClearLimit = limit == nil
 	}
 
 	if !data.IsDisabled.Equal(state.IsDisabled) {
@@ -243,13 +572,28 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		updateReq.IsDisabled = &isDisabled
 	}
 
+	// Changes limited to Terraform-only attributes such as deletion_protection
+	// need no API call.
+	if updateReq.Name == nil && updateReq.Limit == nil && !updateReq.ClearLimit && updateReq.IsDisabled == nil {
+		data.Usage = state.Usage
+		data.CreatedAt = state.CreatedAt
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	apiKey, err := r.client.UpdateApiKey(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update API key, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update API key", err)
 		return
 	}
 
-	data.Usage = types.Float64Value(apiKey.Usage)
+	data.Usage = NewMoneyValue(apiKey.Usage)
+	data.CreatedAt = state.CreatedAt
+
+	if apiKey.CreatedAt != nil {
+		data.CreatedAt = timetypes.NewRFC3339TimeValue(apiKey.CreatedAt.UTC())
+	}
 
 	tflog.Trace(ctx, "updated API key")
 
@@ -257,6 +601,11 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "openrouter_api_key", "delete")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -265,19 +614,88 @@ func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	tflog.Trace(ctx, "deleting API key", map[string]interface{}{
-		"id": data.ID.ValueString(),
-	})
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultApiKeyTimeout)
+	resp.Diagnostics.Append(diags...)
 
-	err := r.client.DeleteApiKey(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key, got error: %s", err))
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Trace(ctx, "deleted API key")
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion Protection Enabled",
+			fmt.Sprintf("The API key %q (%s) has deletion protection enabled and cannot be destroyed. "+
+				"Set deletion_protection to false and apply the change before destroying it.", data.Name.ValueString(), data.ID.ValueString()),
+		)
+		return
+	}
+
+	switch data.OnDestroy.ValueString() {
+	case onDestroyAbandon:
+		tflog.Trace(ctx, "abandoning API key", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+
+		resp.Diagnostics.AddWarning(
+			"API Key Abandoned",
+			fmt.Sprintf("The API key %q (%s) was removed from the Terraform state but still exists in OpenRouter because on_destroy is set to %q.", data.Name.ValueString(), data.ID.ValueString(), onDestroyAbandon),
+		)
+	case onDestroyDisable:
+		tflog.Trace(ctx, "disabling API key", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+
+		isDisabled := true
+		_, err := r.client.UpdateApiKey(ctx, data.ID.ValueString(), &client.UpdateApiKeyRequest{
+			IsDisabled: &isDisabled,
+		})
+		if err != nil {
+			if isNotFound(err) {
+				return
+			}
+			addClientError(&resp.Diagnostics, "Unable to disable API key", err)
+			return
+		}
+
+		tflog.Trace(ctx, "disabled API key")
+	default:
+		tflog.Trace(ctx, "deleting API key", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+
+		err := r.client.DeleteApiKey(ctx, data.ID.ValueString())
+		if err != nil {
+			if isNotFound(err) {
+				return
+			}
+			addClientError(&resp.Diagnostics, "Unable to delete API key", err)
+			return
+		}
+
+		tflog.Trace(ctx, "deleted API key")
+	}
 }
 
 func (r *ApiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "openrouter_api_key", "import")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
\ No newline at end of file
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing_by_name"), false)...)
+}
+
+// isNotFound reports whether err is an API error for a key that no longer
+// exists.
+func isNotFound(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
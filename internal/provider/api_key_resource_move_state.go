@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+const terraformDataProviderAddress = "terraform.io/builtin/terraform"
+
+// movedApiKey holds the API key attributes recovered from the JSON stored by
+// a resource type that previously managed the key.
+type movedApiKey struct {
+	Hash         string        `json:"hash"`
+	Key          string        `json:"key"`
+	Name         string        `json:"name"`
+	Limit        *client.Money `json:"limit"`
+	LimitMinutes *int64        `json:"limit_minutes"`
+	Disabled     *bool         `json:"disabled"`
+}
+
+func (r *ApiKeyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: moveApiKeyStateFromRestApiObject,
+		},
+		{
+			StateMover: moveApiKeyStateFromTerraformData,
+		},
+	}
+}
+
+func moveApiKeyStateFromRestApiObject(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "restapi_object" || req.SourceRawState == nil {
+		return
+	}
+
+	var source struct {
+		ID             string          `json:"id"`
+		Data           json.RawMessage `json:"data"`
+		CreateResponse json.RawMessage `json:"create_response"`
+		ApiResponse    json.RawMessage `json:"api_response"`
+	}
+
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("Unable to decode restapi_object state, got error: %s", err),
+		)
+		return
+	}
+
+	apiKey := movedApiKey{Hash: source.ID}
+	apiKey.merge(source.Data, source.CreateResponse, source.ApiResponse)
+
+	setMovedApiKeyState(ctx, req, resp, apiKey)
+}
+
+func moveApiKeyStateFromTerraformData(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "terraform_data" || req.SourceProviderAddress != terraformDataProviderAddress || req.SourceRawState == nil {
+		return
+	}
+
+	var source struct {
+		Input  json.RawMessage `json:"input"`
+		Output json.RawMessage `json:"output"`
+	}
+
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("Unable to decode terraform_data state, got error: %s", err),
+		)
+		return
+	}
+
+	// The terraform_data id is a random UUID rather than the key hash, so the
+	// hash must come from input or output.
+	var apiKey movedApiKey
+	apiKey.merge(source.Input, source.Output)
+
+	setMovedApiKeyState(ctx, req, resp, apiKey)
+}
+
+// merge decodes each value in turn, later values taking precedence. Values may
+// be JSON objects, JSON encoded strings, terraform_data dynamic values or
+// OpenRouter {"data": ...} response envelopes.
+func (k *movedApiKey) merge(values ...json.RawMessage) {
+	for _, value := range values {
+		var decoded movedApiKey
+		if !decodeMovedApiKey(value, &decoded) {
+			continue
+		}
+
+		if decoded.Hash != "" {
+			k.Hash = decoded.Hash
+		}
+		if decoded.Key != "" {
+			k.Key = decoded.Key
+		}
+		if decoded.Name != "" {
+			k.Name = decoded.Name
+		}
+		if decoded.Limit != nil {
+			k.Limit = decoded.Limit
+		}
+		if decoded.LimitMinutes != nil {
+			k.LimitMinutes = decoded.LimitMinutes
+		}
+		if decoded.Disabled != nil {
+			k.Disabled = decoded.Disabled
+		}
+	}
+}
+
+func decodeMovedApiKey(value json.RawMessage, target *movedApiKey) bool {
+	if len(value) == 0 || string(value) == "null" {
+		return false
+	}
+
+	var encoded string
+	if err := json.Unmarshal(value, &encoded); err == nil {
+		return decodeMovedApiKey(json.RawMessage(encoded), target)
+	}
+
+	var envelope struct {
+		Value json.RawMessage `json:"value"`
+		Type  json.RawMessage `json:"type"`
+		Data  json.RawMessage `json:"data"`
+		Key   string          `json:"key"`
+	}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return false
+	}
+
+	if len(envelope.Value) > 0 && len(envelope.Type) > 0 {
+		return decodeMovedApiKey(envelope.Value, target)
+	}
+
+	if len(envelope.Data) > 0 && decodeMovedApiKey(envelope.Data, target) {
+		if envelope.Key != "" {
+			target.Key = envelope.Key
+		}
+		return true
+	}
+
+	return json.Unmarshal(value, target) == nil
+}
+
+func setMovedApiKeyState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse, apiKey movedApiKey) {
+	if apiKey.Hash == "" || apiKey.Name == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("Unable to find the API key hash and name in the %s state. "+
+				"Ensure the stored JSON contains the \"hash\" and \"name\" fields returned by the OpenRouter API, or import the key instead.", req.SourceTypeName),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "moving API key state", map[string]interface{}{
+		"id":               apiKey.Hash,
+		"source_type_name": req.SourceTypeName,
+	})
+
+	data := ApiKeyResourceModel{
+		ID:                  types.StringValue(apiKey.Hash),
+		Key:                 types.StringNull(),
+		Name:                types.StringValue(apiKey.Name),
+		Limit:               NewMoneyPointerValue(apiKey.Limit),
+		LimitMinutes:        types.Int64PointerValue(apiKey.LimitMinutes),
+		IsDisabled:          types.BoolValue(false),
+		Usage:               NewMoneyNull(),
+		CreatedAt:           timetypes.NewRFC3339Null(),
+		DeletionProtection:  types.BoolValue(false),
+		OnDestroy:           types.StringValue(onDestroyDelete),
+		AdoptExistingByName: types.BoolValue(false),
+		Timeouts:            nullApiKeyTimeouts(),
+	}
+
+	if apiKey.Key != "" {
+		data.Key = types.StringValue(apiKey.Key)
+	}
+
+	if apiKey.Disabled != nil {
+		data.IsDisabled = types.BoolValue(*apiKey.Disabled)
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+}
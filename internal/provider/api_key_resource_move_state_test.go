@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+func TestApiKeyResourceMoveState(t *testing.T) {
+	testCases := map[string]struct {
+		sourceTypeName        string
+		sourceProviderAddress string
+		sourceState           string
+		expected              *ApiKeyResourceModel
+		expectError           bool
+	}{
+		"restapi-object": {
+			sourceTypeName:        "restapi_object",
+			sourceProviderAddress: "registry.terraform.io/mastercard/restapi",
+			sourceState: `{
+				"id": "abc123",
+				"path": "/keys",
+				"data": "{\"name\":\"my-key\",\"limit\":25}",
+				"create_response": "{\"data\":{\"hash\":\"abc123\",\"name\":\"my-key\",\"limit\":25},\"key\":\"sk-or-v1-secret\"}",
+				"api_response": "{\"data\":{\"hash\":\"abc123\",\"name\":\"my-key\",\"limit\":25,\"disabled\":true}}"
+			}`,
+			expected: &ApiKeyResourceModel{
+				ID:                  types.StringValue("abc123"),
+				Key:                 types.StringValue("sk-or-v1-secret"),
+				Name:                types.StringValue("my-key"),
+				Limit:               NewMoneyValue(client.MoneyFromFloat(25)),
+				LimitMinutes:        types.Int64Null(),
+				IsDisabled:          types.BoolValue(true),
+				Usage:               NewMoneyNull(),
+				CreatedAt:           timetypes.NewRFC3339Null(),
+				DeletionProtection:  types.BoolValue(false),
+				OnDestroy:           types.StringValue(onDestroyDelete),
+				AdoptExistingByName: types.BoolValue(false),
+				Timeouts:            nullApiKeyTimeouts(),
+			},
+		},
+		"restapi-object-request-only": {
+			sourceTypeName:        "restapi_object",
+			sourceProviderAddress: "registry.terraform.io/mastercard/restapi",
+			sourceState:           `{"id": "abc123", "data": "{\"name\":\"my-key\",\"limit_minutes\":1440}"}`,
+			expected: &ApiKeyResourceModel{
+				ID:                  types.StringValue("abc123"),
+				Key:                 types.StringNull(),
+				Name:                types.StringValue("my-key"),
+				Limit:               NewMoneyNull(),
+				LimitMinutes:        types.Int64Value(1440),
+				IsDisabled:          types.BoolValue(false),
+				Usage:               NewMoneyNull(),
+				CreatedAt:           timetypes.NewRFC3339Null(),
+				DeletionProtection:  types.BoolValue(false),
+				OnDestroy:           types.StringValue(onDestroyDelete),
+				AdoptExistingByName: types.BoolValue(false),
+				Timeouts:            nullApiKeyTimeouts(),
+			},
+		},
+		"terraform-data": {
+			sourceTypeName:        "terraform_data",
+			sourceProviderAddress: terraformDataProviderAddress,
+			sourceState: `{
+				"id": "6f1c2d9e-0000-0000-0000-000000000000",
+				"input": {"value": {"name": "my-key", "limit": 10.5}, "type": ["object", {"name": "string", "limit": "number"}]},
+				"output": {"value": "{\"data\":{\"hash\":\"def456\",\"name\":\"my-key\",\"limit\":10.5}}", "type": "string"},
+				"triggers_replace": null
+			}`,
+			expected: &ApiKeyResourceModel{
+				ID:                  types.StringValue("def456"),
+				Key:                 types.StringNull(),
+				Name:                types.StringValue("my-key"),
+				Limit:               NewMoneyValue(client.MoneyFromFloat(10.5)),
+				LimitMinutes:        types.Int64Null(),
+				IsDisabled:          types.BoolValue(false),
+				Usage:               NewMoneyNull(),
+				CreatedAt:           timetypes.NewRFC3339Null(),
+				DeletionProtection:  types.BoolValue(false),
+				OnDestroy:           types.StringValue(onDestroyDelete),
+				AdoptExistingByName: types.BoolValue(false),
+				Timeouts:            nullApiKeyTimeouts(),
+			},
+		},
+		"terraform-data-missing-hash": {
+			sourceTypeName:        "terraform_data",
+			sourceProviderAddress: terraformDataProviderAddress,
+			sourceState:           `{"id": "6f1c2d9e", "input": {"value": {"name": "my-key"}, "type": ["object", {"name": "string"}]}, "output": null}`,
+			expectError:           true,
+		},
+		"unsupported-source": {
+			sourceTypeName:        "null_resource",
+			sourceProviderAddress: "registry.terraform.io/hashicorp/null",
+			sourceState:           `{"id": "abc123"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &ApiKeyResource{}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			req := resource.MoveStateRequest{
+				SourceTypeName:        tc.sourceTypeName,
+				SourceProviderAddress: tc.sourceProviderAddress,
+				SourceRawState: &tfprotov6.RawState{
+					JSON: []byte(tc.sourceState),
+				},
+			}
+			resp := resource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			for _, mover := range r.MoveState(ctx) {
+				mover.StateMover(ctx, req, &resp)
+
+				if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+					break
+				}
+			}
+
+			if tc.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			if tc.expected == nil {
+				if !resp.TargetState.Raw.IsNull() {
+					t.Fatalf("expected no state to be moved, got %s", resp.TargetState.Raw)
+				}
+				return
+			}
+
+			expectedState := tfsdk.State{
+				Schema: schemaResp.Schema,
+			}
+			if diags := expectedState.Set(ctx, tc.expected); diags.HasError() {
+				t.Fatalf("unexpected error building expected state: %v", diags)
+			}
+
+			if !resp.TargetState.Raw.Equal(expectedState.Raw) {
+				t.Errorf("expected %s, got %s", expectedState.Raw, resp.TargetState.Raw)
+			}
+		})
+	}
+}
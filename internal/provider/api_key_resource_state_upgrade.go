@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+// ApiKeyResourceModelV0 is the openrouter_api_key state before created_at
+// became an RFC3339 timetype.
+type ApiKeyResourceModelV0 struct {
+	ID           types.String  `tfsdk:"id"`
+	Key          types.String  `tfsdk:"key"`
+	Name         types.String  `tfsdk:"name"`
+	Limit        types.Float64 `tfsdk:"limit"`
+	LimitMinutes types.Int64   `tfsdk:"limit_minutes"`
+	IsDisabled   types.Bool    `tfsdk:"is_disabled"`
+	Usage        types.Float64 `tfsdk:"usage"`
+	CreatedAt    types.String  `tfsdk:"created_at"`
+}
+
+func (r *ApiKeyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := apiKeyResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeApiKeyResourceStateV0toV1,
+		},
+	}
+}
+
+func apiKeyResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"limit": schema.Float64Attribute{
+				Optional: true,
+			},
+			"limit_minutes": schema.Int64Attribute{
+				Optional: true,
+			},
+			"is_disabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"usage": schema.Float64Attribute{
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func upgradeApiKeyResourceStateV0toV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorState ApiKeyResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgradedState := ApiKeyResourceModel{
+		ID:                  priorState.ID,
+		Key:                 priorState.Key,
+		Name:                priorState.Name,
+		Limit:               moneyFromFloat64(priorState.Limit),
+		LimitMinutes:        priorState.LimitMinutes,
+		IsDisabled:          priorState.IsDisabled,
+		Usage:               moneyFromFloat64(priorState.Usage),
+		CreatedAt:           timetypes.NewRFC3339Null(),
+		DeletionProtection:  types.BoolValue(false),
+		OnDestroy:           types.StringValue(onDestroyDelete),
+		AdoptExistingByName: types.BoolValue(false),
+		Timeouts:            nullApiKeyTimeouts(),
+	}
+
+	if !priorState.CreatedAt.IsNull() && !priorState.CreatedAt.IsUnknown() {
+		createdAt, err := time.Parse(time.RFC3339, priorState.CreatedAt.ValueString())
+		if err != nil {
+			tflog.Warn(ctx, "discarding unparseable created_at during state upgrade, it will be refreshed on the next read", map[string]interface{}{
+				"created_at": priorState.CreatedAt.ValueString(),
+			})
+		} else {
+			upgradedState.CreatedAt = timetypes.NewRFC3339TimeValue(createdAt.UTC())
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+}
+
+// moneyFromFloat64 converts a version 0 float attribute to an amount, using
+// the shortest decimal representation of the float.
+func moneyFromFloat64(value types.Float64) MoneyValue {
+	if value.IsNull() || value.IsUnknown() {
+		return NewMoneyNull()
+	}
+
+	return NewMoneyValue(client.MoneyFromFloat(value.ValueFloat64()))
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+func apiKeyResourceStateV0(t *testing.T, createdAt tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	schemaV0 := apiKeyResourceSchemaV0()
+	objectType := schemaV0.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	return tfsdk.State{
+		Schema: schemaV0,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":            tftypes.NewValue(tftypes.String, "abc123"),
+			"key":           tftypes.NewValue(tftypes.String, "sk-or-v1-secret"),
+			"name":          tftypes.NewValue(tftypes.String, "my-key"),
+			"limit":         tftypes.NewValue(tftypes.Number, 10.5),
+			"limit_minutes": tftypes.NewValue(tftypes.Number, nil),
+			"is_disabled":   tftypes.NewValue(tftypes.Bool, false),
+			"usage":         tftypes.NewValue(tftypes.Number, 1.25),
+			"created_at":    createdAt,
+		}),
+	}
+}
+
+func TestApiKeyResourceUpgradeStateV0toV1(t *testing.T) {
+	testCases := map[string]struct {
+		createdAt         tftypes.Value
+		expectedCreatedAt timetypes.RFC3339
+	}{
+		"created-at": {
+			createdAt:         tftypes.NewValue(tftypes.String, "2024-05-01T12:30:00Z"),
+			expectedCreatedAt: timetypes.NewRFC3339ValueMust("2024-05-01T12:30:00Z"),
+		},
+		"created-at-offset": {
+			createdAt:         tftypes.NewValue(tftypes.String, "2024-05-01T14:30:00+02:00"),
+			expectedCreatedAt: timetypes.NewRFC3339ValueMust("2024-05-01T12:30:00Z"),
+		},
+		"created-at-null": {
+			createdAt:         tftypes.NewValue(tftypes.String, nil),
+			expectedCreatedAt: timetypes.NewRFC3339Null(),
+		},
+		"created-at-invalid": {
+			createdAt:         tftypes.NewValue(tftypes.String, "yesterday"),
+			expectedCreatedAt: timetypes.NewRFC3339Null(),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &ApiKeyResource{}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			if schemaResp.Schema.Version != 1 {
+				t.Fatalf("expected schema version 1, got %d", schemaResp.Schema.Version)
+			}
+
+			upgrader, ok := r.UpgradeState(ctx)[0]
+			if !ok {
+				t.Fatal("expected a state upgrader from version 0")
+			}
+
+			priorState := apiKeyResourceStateV0(t, tc.createdAt)
+			req := resource.UpgradeStateRequest{
+				State: &priorState,
+			}
+			resp := resource.UpgradeStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+				},
+			}
+
+			upgrader.StateUpgrader(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			expected := ApiKeyResourceModel{
+				ID:                  types.StringValue("abc123"),
+				Key:                 types.StringValue("sk-or-v1-secret"),
+				Name:                types.StringValue("my-key"),
+				Limit:               NewMoneyValue(client.MoneyFromFloat(10.5)),
+				LimitMinutes:        types.Int64Null(),
+				IsDisabled:          types.BoolValue(false),
+				Usage:               NewMoneyValue(client.MoneyFromFloat(1.25)),
+				CreatedAt:           tc.expectedCreatedAt,
+				DeletionProtection:  types.BoolValue(false),
+				OnDestroy:           types.StringValue(onDestroyDelete),
+				AdoptExistingByName: types.BoolValue(false),
+				Timeouts:            nullApiKeyTimeouts(),
+			}
+
+			expectedState := tfsdk.State{
+				Schema: schemaResp.Schema,
+			}
+			if diags := expectedState.Set(ctx, expected); diags.HasError() {
+				t.Fatalf("unexpected error building expected state: %v", diags)
+			}
+
+			if !resp.State.Raw.Equal(expectedState.Raw) {
+				t.Errorf("expected %s, got %s", expectedState.Raw, resp.State.Raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,860 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+	"github.com/standujar/terraform-provider-openrouter/internal/fakeopenrouter"
+)
+
+func newTestApiKeyResource(t *testing.T, api client.API) (*ApiKeyResource, fwresource.SchemaResponse) {
+	t.Helper()
+
+	r := NewApiKeyResource().(*ApiKeyResource)
+	configureResp := &fwresource.ConfigureResponse{}
+	r.Configure(context.Background(), fwresource.ConfigureRequest{ProviderData: api}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+
+	return r, schemaResp
+}
+
+func testApiKeyPlan(onDestroy string) ApiKeyResourceModel {
+	return ApiKeyResourceModel{
+		ID:                  types.StringUnknown(),
+		Key:                 types.StringUnknown(),
+		Name:                types.StringValue("test"),
+		Limit:               NewMoneyValue(client.MoneyFromFloat(10)),
+		LimitMinutes:        types.Int64Null(),
+		IsDisabled:          types.BoolValue(false),
+		Usage:               NewMoneyUnknown(),
+		CreatedAt:           timetypes.NewRFC3339Unknown(),
+		DeletionProtection:  types.BoolValue(false),
+		OnDestroy:           types.StringValue(onDestroy),
+		AdoptExistingByName: types.BoolValue(false),
+		Timeouts:            nullApiKeyTimeouts(),
+	}
+}
+
+// testApiKeyTimeouts returns a timeouts block setting only the timeout of
+// operation.
+func testApiKeyTimeouts(ctx context.Context, operation, timeout string) timeouts.Value {
+	attributeTypes := nullApiKeyTimeouts().AttributeTypes(ctx)
+
+	values := make(map[string]attr.Value, len(attributeTypes))
+	for name := range attributeTypes {
+		values[name] = types.StringNull()
+	}
+	values[operation] = types.StringValue(timeout)
+
+	return timeouts.Value{Object: types.ObjectValueMust(attributeTypes, values)}
+}
+
+func applyTestApiKeyCreate(t *testing.T, r *ApiKeyResource, schemaResp fwresource.SchemaResponse, data ApiKeyResourceModel) *fwresource.CreateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected plan diagnostics: %v", diags)
+	}
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+	return resp
+}
+
+func createTestApiKey(t *testing.T, r *ApiKeyResource, schemaResp fwresource.SchemaResponse, onDestroy string) tfsdk.State {
+	t.Helper()
+
+	resp := applyTestApiKeyCreate(t, r, schemaResp, testApiKeyPlan(onDestroy))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.State
+}
+
+func TestApiKeyResourceCreateRead(t *testing.T) {
+	ctx := context.Background()
+	api := client.NewMemoryClient()
+	r, schemaResp := newTestApiKeyResource(t, api)
+
+	state := createTestApiKey(t, r, schemaResp, onDestroyDelete)
+
+	var created ApiKeyResourceModel
+	if diags := state.Get(ctx, &created); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	if created.ID.ValueString() == "" || !strings.HasPrefix(created.Key.ValueString(), "sk-or-v1-") {
+		t.Fatalf("expected id and key to be set, got %q and %q", created.ID.ValueString(), created.Key.ValueString())
+	}
+	if created.CreatedAt.IsNull() || created.CreatedAt.IsUnknown() {
+		t.Errorf("expected created_at to be set")
+	}
+
+	// Simulate the key being disabled outside of Terraform.
+	apiKey, err := api.GetApiKey(ctx, created.ID.ValueString())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	apiKey.IsDisabled = true
+	api.Put(*apiKey)
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ApiKeyResourceModel
+	if diags := readResp.State.Get(ctx, &read); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	if !read.IsDisabled.ValueBool() {
+		t.Errorf("expected drift of is_disabled to be read")
+	}
+	if read.Key.ValueString() != created.Key.ValueString() {
+		t.Errorf("expected key to be kept in state")
+	}
+
+	// Simulate the key being deleted outside of Terraform.
+	api.Remove(created.ID.ValueString())
+
+	readResp = &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("expected deleted key to be removed from state")
+	}
+}
+
+func TestApiKeyResourceDelete(t *testing.T) {
+	testCases := map[string]struct {
+		onDestroy      string
+		expectExists   bool
+		expectDisabled bool
+		expectWarnings int
+	}{
+		onDestroyDelete:  {onDestroy: onDestroyDelete},
+		onDestroyDisable: {onDestroy: onDestroyDisable, expectExists: true, expectDisabled: true},
+		onDestroyAbandon: {onDestroy: onDestroyAbandon, expectExists: true, expectWarnings: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			api := client.NewMemoryClient()
+			r, schemaResp := newTestApiKeyResource(t, api)
+
+			state := createTestApiKey(t, r, schemaResp, testCase.onDestroy)
+
+			var created ApiKeyResourceModel
+			if diags := state.Get(ctx, &created); diags.HasError() {
+				t.Fatalf("unexpected state diagnostics: %v", diags)
+			}
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected delete diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.Diagnostics.WarningsCount() != testCase.expectWarnings {
+				t.Errorf("expected %d warnings, got %v", testCase.expectWarnings, resp.Diagnostics)
+			}
+
+			apiKey, err := api.GetApiKey(ctx, created.ID.ValueString())
+			if testCase.expectExists != (err == nil) {
+				t.Fatalf("expected key to exist: %t, got error: %v", testCase.expectExists, err)
+			}
+			if apiKey != nil && apiKey.IsDisabled != testCase.expectDisabled {
+				t.Errorf("expected key to be disabled: %t", testCase.expectDisabled)
+			}
+		})
+	}
+}
+
+func TestApiKeyResourceCreateAdoptExistingByName(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Now().Add(-24 * time.Hour).UTC()
+	limit := client.MoneyFromFloat(5)
+
+	testCases := map[string]struct {
+		existing    []string
+		expectError bool
+	}{
+		"unique":    {existing: []string{"test", "other"}},
+		"ambiguous": {existing: []string{"test", "test"}, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := client.NewMemoryClient()
+			for i, existingName := range testCase.existing {
+				api.Put(client.ApiKeyInfo{
+					ID:        fmt.Sprintf("existing%d", i),
+					Name:      existingName,
+					Limit:     &limit,
+					CreatedAt: &createdAt,
+				})
+			}
+
+			r, schemaResp := newTestApiKeyResource(t, api)
+
+			data := testApiKeyPlan(onDestroyDelete)
+			data.Limit = NewMoneyNull()
+			data.AdoptExistingByName = types.BoolValue(true)
+
+			resp := applyTestApiKeyCreate(t, r, schemaResp, data)
+
+			keys, err := api.ListAllApiKeys(ctx, &client.ListApiKeysRequest{IncludeDisabled: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(keys) != len(testCase.existing) {
+				t.Errorf("expected no key to be created, got %d keys", len(keys))
+			}
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error for an ambiguous name")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected create diagnostics: %v", resp.Diagnostics)
+			}
+
+			var adopted ApiKeyResourceModel
+			if diags := resp.State.Get(ctx, &adopted); diags.HasError() {
+				t.Fatalf("unexpected state diagnostics: %v", diags)
+			}
+			if adopted.ID.ValueString() != "existing0" {
+				t.Errorf("expected key %q to be adopted, got %q", "existing0", adopted.ID.ValueString())
+			}
+
+			apiKey, err := api.GetApiKey(ctx, "existing0")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if apiKey.Limit != nil {
+				t.Errorf("expected the limit of the adopted key to be cleared, got %s", apiKey.Limit)
+			}
+		})
+	}
+}
+
+// failingCreateAPI fails every create call, optionally after creating the key
+// or waiting for the call to time out, as a lost response would. It also fails
+// every update with updateErr, if set.
+type failingCreateAPI struct {
+	client.API
+	err            error
+	createKey      bool
+	waitForTimeout bool
+	updateErr      error
+}
+
+func (a failingCreateAPI) CreateApiKey(ctx context.Context, req *client.CreateApiKeyRequest) (*client.CreateApiKeyResponse, error) {
+	if a.createKey {
+		if _, err := a.API.CreateApiKey(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.waitForTimeout {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return nil, a.err
+}
+
+func (a failingCreateAPI) ListAllApiKeys(ctx context.Context, params *client.ListApiKeysRequest) ([]client.ApiKeyInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.API.ListAllApiKeys(ctx, params)
+}
+
+func (a failingCreateAPI) UpdateApiKey(ctx context.Context, hash string, req *client.UpdateApiKeyRequest) (*client.ApiKeyInfo, error) {
+	if a.updateErr != nil {
+		return nil, a.updateErr
+	}
+	return a.API.UpdateApiKey(ctx, hash, req)
+}
+
+func TestApiKeyResourceCreateReconcile(t *testing.T) {
+	ctx := context.Background()
+	serverError := &client.APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}
+
+	testCases := map[string]struct {
+		api           failingCreateAPI
+		existing      bool
+		disabled      bool
+		createTimeout string
+		expectAdopted bool
+		expectError   bool
+	}{
+		"server error": {
+			api:           failingCreateAPI{err: serverError, createKey: true},
+			expectAdopted: true,
+		},
+		"expired create timeout": {
+			api:           failingCreateAPI{createKey: true, waitForTimeout: true},
+			createTimeout: "50ms",
+			expectAdopted: true,
+		},
+		"failed update": {
+			api:           failingCreateAPI{err: serverError, createKey: true, updateErr: serverError},
+			disabled:      true,
+			expectAdopted: true,
+			expectError:   true,
+		},
+		"client error": {
+			api:         failingCreateAPI{err: &client.APIError{StatusCode: http.StatusBadRequest, Message: "Invalid limit"}, createKey: true},
+			expectError: true,
+		},
+		"key named the same before the call": {
+			api:         failingCreateAPI{err: serverError},
+			existing:    true,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := client.NewMemoryClient()
+			if testCase.existing {
+				createdAt := time.Now().UTC()
+				api.Put(client.ApiKeyInfo{ID: "existing", Name: "test", CreatedAt: &createdAt})
+			}
+
+			testCase.api.API = api
+			r, schemaResp := newTestApiKeyResource(t, testCase.api)
+
+			data := testApiKeyPlan(onDestroyDelete)
+			data.IsDisabled = types.BoolValue(testCase.disabled)
+			if testCase.createTimeout != "" {
+				data.Timeouts = testApiKeyTimeouts(ctx, "create", testCase.createTimeout)
+			}
+
+			resp := applyTestApiKeyCreate(t, r, schemaResp, data)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if !testCase.expectAdopted {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected no key to be saved")
+				}
+				return
+			}
+
+			keys, err := api.ListAllApiKeys(ctx, &client.ListApiKeysRequest{IncludeDisabled: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(keys) != 1 {
+				t.Fatalf("expected a single key, got %d", len(keys))
+			}
+
+			var adopted ApiKeyResourceModel
+			if diags := resp.State.Get(ctx, &adopted); diags.HasError() {
+				t.Fatalf("unexpected state diagnostics: %v", diags)
+			}
+			if adopted.ID.ValueString() != keys[0].ID {
+				t.Errorf("expected key %q to be saved, got %q", keys[0].ID, adopted.ID.ValueString())
+			}
+
+			var warned bool
+			for _, d := range resp.Diagnostics.Warnings() {
+				warned = warned || d.Summary() == "Created API Key Adopted After Error"
+			}
+			if !warned {
+				t.Errorf("expected the adoption to be reported, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestApiKeyResourceCreateReconcileStalled(t *testing.T) {
+	ctx := context.Background()
+
+	// The create call starts, and the server creates the key, well before the
+	// default create timeout: the response stalls for longer than that.
+	start := time.Now().Add(-2 * defaultApiKeyTimeout).UTC()
+
+	server := fakeopenrouter.NewServer(fakeopenrouter.WithClock(func() time.Time { return start.Add(time.Second) }))
+	t.Cleanup(server.Close)
+
+	endpoint := server.URL + "/api/v1"
+	r, schemaResp := newTestApiKeyResource(t, client.NewClient(fakeopenrouter.ProvisioningKey, &endpoint))
+	r.now = func() time.Time { return start }
+
+	server.InjectFault(fakeopenrouter.Fault{Method: http.MethodPost, PathPrefix: "/keys", Times: 1, Stall: 10 * time.Second})
+
+	data := testApiKeyPlan(onDestroyDelete)
+	data.Timeouts = testApiKeyTimeouts(ctx, "create", "100ms")
+
+	resp := applyTestApiKeyCreate(t, r, schemaResp, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", resp.Diagnostics)
+	}
+
+	keys := server.Keys()
+	if len(keys) != 1 {
+		t.Fatalf("expected a single key, got %d", len(keys))
+	}
+
+	var adopted ApiKeyResourceModel
+	if diags := resp.State.Get(ctx, &adopted); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	if adopted.ID.ValueString() != keys[0].Hash {
+		t.Errorf("expected key %q to be adopted, got %q", keys[0].Hash, adopted.ID.ValueString())
+	}
+}
+
+func TestApiKeyResourceReadTimeout(t *testing.T) {
+	ctx := context.Background()
+	server := fakeopenrouter.NewServer()
+	t.Cleanup(server.Close)
+
+	endpoint := server.URL + "/api/v1"
+	r, schemaResp := newTestApiKeyResource(t, client.NewClient(fakeopenrouter.ProvisioningKey, &endpoint))
+
+	state := createTestApiKey(t, r, schemaResp, onDestroyDelete)
+
+	var data ApiKeyResourceModel
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	data.Timeouts = testApiKeyTimeouts(ctx, "read", "100ms")
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+
+	server.InjectFault(fakeopenrouter.Fault{Method: http.MethodGet, PathPrefix: "/keys/", Latency: 10 * time.Second})
+
+	start := time.Now()
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the read timeout to fail the read")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the read to be cancelled after its timeout, took %s", elapsed)
+	}
+}
+
+func TestApiKeyResourceDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+	api := client.NewMemoryClient()
+	r, schemaResp := newTestApiKeyResource(t, api)
+
+	state := createTestApiKey(t, r, schemaResp, onDestroyDelete)
+	if diags := state.SetAttribute(ctx, path.Root("deletion_protection"), true); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+
+	var created ApiKeyResourceModel
+	if diags := state.Get(ctx, &created); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected deletion protection to fail the delete")
+	}
+
+	if _, err := api.GetApiKey(ctx, created.ID.ValueString()); err != nil {
+		t.Errorf("expected protected key to still exist, got error: %s", err)
+	}
+}
+
+// failingUpdateAPI fails every update with a transport error.
+type failingUpdateAPI struct {
+	client.API
+	err error
+}
+
+func (a failingUpdateAPI) UpdateApiKey(ctx context.Context, hash string, req *client.UpdateApiKeyRequest) (*client.ApiKeyInfo, error) {
+	return nil, a.err
+}
+
+func TestApiKeyResourceDeleteDisableTransportError(t *testing.T) {
+	ctx := context.Background()
+	api := client.NewMemoryClient()
+	r, schemaResp := newTestApiKeyResource(t, api)
+
+	state := createTestApiKey(t, r, schemaResp, onDestroyDisable)
+
+	// The error of a failed request names its URL, which may contain "404".
+	r.client = failingUpdateAPI{
+		API: api,
+		err: fmt.Errorf("request failed: Patch \"https://openrouter.ai/api/v1/keys/ab404c\": %w", context.DeadlineExceeded),
+	}
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected a transport error to fail the delete")
+	}
+}
+
+func TestApiKeyResourceDeleteRemoved(t *testing.T) {
+	for _, onDestroy := range []string{onDestroyDelete, onDestroyDisable} {
+		t.Run(onDestroy, func(t *testing.T) {
+			ctx := context.Background()
+			server := fakeopenrouter.NewServer()
+			t.Cleanup(server.Close)
+
+			endpoint := server.URL + "/api/v1"
+			r, schemaResp := newTestApiKeyResource(t, client.NewClient(fakeopenrouter.ProvisioningKey, &endpoint))
+
+			state := createTestApiKey(t, r, schemaResp, onDestroy)
+
+			var data ApiKeyResourceModel
+			if diags := state.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("unexpected state diagnostics: %v", diags)
+			}
+			server.RemoveKey(data.ID.ValueString())
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected a key removed outside Terraform to be deleted, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestApiKeyResourceUpdateRemoveLimit(t *testing.T) {
+	ctx := context.Background()
+	api := client.NewMemoryClient()
+	r, schemaResp := newTestApiKeyResource(t, api)
+
+	state := createTestApiKey(t, r, schemaResp, onDestroyDelete)
+
+	var data ApiKeyResourceModel
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	data.Limit = NewMoneyNull()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected plan diagnostics: %v", diags)
+	}
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", resp.Diagnostics)
+	}
+
+	apiKey, err := api.GetApiKey(ctx, data.ID.ValueString())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if apiKey.Limit != nil {
+		t.Errorf("expected limit to be removed, got %s", apiKey.Limit)
+	}
+}
+
+func TestApiKeyResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	r, schemaResp := newTestApiKeyResource(t, client.NewMemoryClient())
+
+	resp := &fwresource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "abc123"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import diagnostics: %v", resp.Diagnostics)
+	}
+
+	// Attributes with defaults must match them so that imported keys plan no
+	// changes.
+	var imported ApiKeyResourceModel
+	if diags := resp.State.Get(ctx, &imported); diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+	if imported.ID.ValueString() != "abc123" {
+		t.Errorf("expected id %q, got %q", "abc123", imported.ID.ValueString())
+	}
+	if !imported.DeletionProtection.Equal(types.BoolValue(false)) {
+		t.Errorf("expected deletion_protection false, got %s", imported.DeletionProtection)
+	}
+	if !imported.OnDestroy.Equal(types.StringValue(onDestroyDelete)) {
+		t.Errorf("expected on_destroy %q, got %s", onDestroyDelete, imported.OnDestroy)
+	}
+	if !imported.AdoptExistingByName.Equal(types.BoolValue(false)) {
+		t.Errorf("expected adopt_existing_by_name false, got %s", imported.AdoptExistingByName)
+	}
+}
+
+func TestAccApiKeyResource(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiKeyDestroyed(backend),
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(testAccApiKeyResourceConfig(name, 10, false)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("openrouter_api_key.test", "id"),
+					resource.TestCheckResourceAttrSet("openrouter_api_key.test", "key"),
+					resource.TestCheckResourceAttrSet("openrouter_api_key.test", "created_at"),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "name", name),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "limit", "10"),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "is_disabled", "false"),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "on_destroy", "delete"),
+				),
+			},
+			{
+				Config: backend.config(testAccApiKeyResourceConfig(name+"-updated", 20, true)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "name", name+"-updated"),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "limit", "20"),
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "is_disabled", "true"),
+					testAccCheckApiKeyDisabled(backend, "openrouter_api_key.test", true),
+				),
+			},
+			{
+				ResourceName:            "openrouter_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
+func TestAccApiKeyResource_onDestroyDisable(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+
+	var hash string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			defer func() { _ = backend.client().DeleteApiKey(context.Background(), hash) }()
+
+			apiKey, err := backend.client().GetApiKey(context.Background(), hash)
+			if err != nil {
+				return fmt.Errorf("expected key %s to be kept on destroy, got error: %w", hash, err)
+			}
+			if !apiKey.IsDisabled {
+				return fmt.Errorf("expected key %s to be disabled on destroy", hash)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(fmt.Sprintf(`
+resource "openrouter_api_key" "test" {
+  name       = %q
+  on_destroy = "disable"
+}
+`, name)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "on_destroy", "disable"),
+					func(s *terraform.State) error {
+						hash = s.RootModule().Resources["openrouter_api_key.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiKeyResource_limit(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+	zero := client.MoneyFromFloat(0)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiKeyDestroyed(backend),
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(testAccApiKeyResourceLimitConfig(name, "0.1")),
+				Check:  resource.TestCheckResourceAttr("openrouter_api_key.test", "limit", "0.1"),
+			},
+			{
+				Config: backend.config(testAccApiKeyResourceLimitConfig(name, "")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("openrouter_api_key.test", "limit"),
+					testAccCheckApiKeyLimit(backend, "openrouter_api_key.test", nil),
+				),
+			},
+			{
+				Config: backend.config(testAccApiKeyResourceLimitConfig(name, "0")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("openrouter_api_key.test", "limit", "0"),
+					testAccCheckApiKeyLimit(backend, "openrouter_api_key.test", &zero),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiKeyResource_disappears(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiKeyDestroyed(backend),
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(testAccApiKeyResourceConfig(name, 10, false)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckApiKeyDeletedOutOfBand(backend, "openrouter_api_key.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccApiKeyResourceConfig(name string, limit float64, disabled bool) string {
+	return fmt.Sprintf(`
+resource "openrouter_api_key" "test" {
+  name        = %q
+  limit       = %g
+  is_disabled = %t
+}
+`, name, limit, disabled)
+}
+
+// testAccApiKeyResourceLimitConfig omits the limit when limit is empty.
+func testAccApiKeyResourceLimitConfig(name, limit string) string {
+	if limit == "" {
+		return fmt.Sprintf(`
+resource "openrouter_api_key" "test" {
+  name = %q
+}
+`, name)
+	}
+
+	return fmt.Sprintf(`
+resource "openrouter_api_key" "test" {
+  name  = %q
+  limit = %s
+}
+`, name, limit)
+}
+
+func testAccCheckApiKeyDestroyed(backend *testAccBackend) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "openrouter_api_key" {
+				continue
+			}
+
+			_, err := backend.client().GetApiKey(context.Background(), rs.Primary.ID)
+
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("API key %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckApiKeyDisabled(backend *testAccBackend, resourceName string, disabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		apiKey, err := backend.client().GetApiKey(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if apiKey.IsDisabled != disabled {
+			return fmt.Errorf("expected API key %s disabled to be %t, got %t", rs.Primary.ID, disabled, apiKey.IsDisabled)
+		}
+		return nil
+	}
+}
+
+// testAccCheckApiKeyLimit checks the limit of the key in the API, where nil
+// means no limit.
+func testAccCheckApiKeyLimit(backend *testAccBackend, resourceName string, limit *client.Money) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		apiKey, err := backend.client().GetApiKey(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if (apiKey.Limit == nil) != (limit == nil) || (limit != nil && !apiKey.Limit.Equal(*limit)) {
+			return fmt.Errorf("expected API key %s limit to be %v, got %v", rs.Primary.ID, limit, apiKey.Limit)
+		}
+		return nil
+	}
+}
+
+func testAccCheckApiKeyDeletedOutOfBand(backend *testAccBackend, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		return backend.client().DeleteApiKey(context.Background(), rs.Primary.ID)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/standujar/terraform-provider-openrouter/internal/client"
+	"github.com/standujar/terraform-provider-openrouter/internal/telemetry"
 )
 
 var _ datasource.DataSource = &ApiKeysDataSource{}
@@ -18,23 +20,24 @@ func NewApiKeysDataSource() datasource.DataSource {
 }
 
 type ApiKeysDataSource struct {
-	client *client.Client
+	client client.API
 }
 
 type ApiKeysDataSourceModel struct {
-	IncludeDisabled types.Bool `tfsdk:"include_disabled"`
-	Keys            []ApiKeyModel `tfsdk:"keys"`
+	IncludeDisabled types.Bool     `tfsdk:"include_disabled"`
+	Keys            []ApiKeyModel  `tfsdk:"keys"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ApiKeyModel struct {
-	ID             types.String  `tfsdk:"id"`
-	Name           types.String  `tfsdk:"name"`
-	IsProvisioner  types.Bool    `tfsdk:"is_provisioner"`
-	Limit          types.Float64 `tfsdk:"limit"`
-	LimitMinutes   types.Int64   `tfsdk:"limit_minutes"`
-	Usage          types.Float64 `tfsdk:"usage"`
-	IsDisabled     types.Bool    `tfsdk:"is_disabled"`
-	CreatedAt      types.String  `tfsdk:"created_at"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	IsProvisioner types.Bool   `tfsdk:"is_provisioner"`
+	Limit         MoneyValue   `tfsdk:"limit"`
+	LimitMinutes  types.Int64  `tfsdk:"limit_minutes"`
+	Usage         MoneyValue   `tfsdk:"usage"`
+	IsDisabled    types.Bool   `tfsdk:"is_disabled"`
+	CreatedAt     types.String `tfsdk:"created_at"`
 }
 
 func (d *ApiKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,16 +70,18 @@ func (d *ApiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							MarkdownDescription: "Whether the API key is a provisioner key.",
 							Computed:            true,
 						},
-						"limit": schema.Float64Attribute{
+						"limit": schema.NumberAttribute{
 							MarkdownDescription: "The spend limit for the API key in USD.",
+							CustomType:          MoneyType{},
 							Computed:            true,
 						},
 						"limit_minutes": schema.Int64Attribute{
 							MarkdownDescription: "The time limit for the API key in minutes.",
 							Computed:            true,
 						},
-						"usage": schema.Float64Attribute{
+						"usage": schema.NumberAttribute{
 							MarkdownDescription: "The current usage of the API key in USD.",
+							CustomType:          MoneyType{},
 							Computed:            true,
 						},
 						"is_disabled": schema.BoolAttribute{
@@ -91,6 +96,10 @@ func (d *ApiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
@@ -99,12 +108,12 @@ func (d *ApiKeysDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -114,6 +123,11 @@ func (d *ApiKeysDataSource) Configure(ctx context.Context, req datasource.Config
 }
 
 func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
+	ctx, span := telemetry.StartOperation(ctx, "data.openrouter_api_keys", "read")
+	defer func() { telemetry.EndOperation(span, resp.Diagnostics) }()
+
 	var data ApiKeysDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -122,6 +136,16 @@ func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultDataSourceReadTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Trace(ctx, "reading API keys data source")
 
 	params := &client.ListApiKeysRequest{
@@ -130,7 +154,7 @@ func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	apiKeys, err := d.client.ListApiKeys(ctx, params)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API keys, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to read API keys", err)
 		return
 	}
 
@@ -140,15 +164,11 @@ func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 			ID:            types.StringValue(apiKey.ID),
 			Name:          types.StringValue(apiKey.Name),
 			IsProvisioner: types.BoolValue(apiKey.IsProvisioner),
-			Usage:         types.Float64Value(apiKey.Usage),
+			Usage:         NewMoneyValue(apiKey.Usage),
 			IsDisabled:    types.BoolValue(apiKey.IsDisabled),
 		}
 
-		if apiKey.Limit != nil {
-			key.Limit = types.Float64Value(*apiKey.Limit)
-		} else {
-			key.Limit = types.Float64Null()
-		}
+		key.Limit = NewMoneyPointerValue(apiKey.Limit)
 
 		if apiKey.LimitMinutes != nil {
 			key.LimitMinutes = types.Int64Value(int64(*apiKey.LimitMinutes))
@@ -166,4 +186,4 @@ func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
\ No newline at end of file
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApiKeysDataSource(t *testing.T) {
+	backend := newTestAccBackend(t)
+	name := testAccName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { backend.preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckApiKeyDestroyed(backend),
+		Steps: []resource.TestStep{
+			{
+				Config: backend.config(testAccApiKeyResourceConfig(name, 10, true) + `
+data "openrouter_api_keys" "enabled" {
+  depends_on = [openrouter_api_key.test]
+}
+
+data "openrouter_api_keys" "all" {
+  include_disabled = true
+
+  depends_on = [openrouter_api_key.test]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.openrouter_api_keys.all", "keys.*", map[string]string{
+						"name":        name,
+						"limit":       "10",
+						"is_disabled": "true",
+					}),
+					testAccCheckNoKeyNamed("data.openrouter_api_keys.enabled", name),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+// addClientError adds the error of a failed API call to diags, described by
+// action such as "Unable to read API key". Errors caused by the configured
+// credentials or account get a summary of their own and remediation text, as
+// retrying cannot fix them.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	detail := fmt.Sprintf("%s, got error: %s", action, err)
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError("Client Error", detail)
+		return
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		diags.AddError(
+			"Invalid OpenRouter API Key",
+			detail+"\n\nOpenRouter rejected the API key. Check that the key from the api_key provider argument, the selected profile, "+
+				"the credential_process command or the OPENROUTER_API_KEY environment variable exists and is not disabled, and that it is a provisioning key, "+
+				"which managing API keys requires. Provisioning keys are created at https://openrouter.ai/settings/provisioning-keys.",
+		)
+	case apiErr.StatusCode == http.StatusPaymentRequired:
+		diags.AddError(
+			"Insufficient OpenRouter Credits",
+			detail+"\n\nThe OpenRouter account does not have enough credits for this request. "+
+				"Add credits at https://openrouter.ai/settings/credits and apply again.",
+		)
+	case apiErr.StatusCode == http.StatusForbidden && len(apiErr.Reasons) == 0:
+		diags.AddError(
+			"OpenRouter Provisioning Key Required",
+			detail+"\n\nThe API key is not allowed to manage API keys. Provide a provisioning key, created at "+
+				"https://openrouter.ai/settings/provisioning-keys, instead of an inference key through the api_key provider argument, "+
+				"the selected profile, the credential_process command or the OPENROUTER_API_KEY environment variable.",
+		)
+	default:
+		diags.AddError("Client Error", detail)
+	}
+}
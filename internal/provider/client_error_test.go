@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+func TestAddClientError(t *testing.T) {
+	testCases := map[string]struct {
+		err             error
+		expectedSummary string
+		expectedDetail  string
+	}{
+		"unauthorized": {
+			err:             &client.APIError{StatusCode: http.StatusUnauthorized, Message: "No auth credentials found"},
+			expectedSummary: "Invalid OpenRouter API Key",
+			expectedDetail:  "the selected profile, the credential_process command",
+		},
+		"payment required": {
+			err:             &client.APIError{StatusCode: http.StatusPaymentRequired, Message: "Insufficient credits"},
+			expectedSummary: "Insufficient OpenRouter Credits",
+			expectedDetail:  "https://openrouter.ai/settings/credits",
+		},
+		"forbidden": {
+			err:             &client.APIError{StatusCode: http.StatusForbidden, Message: "Forbidden"},
+			expectedSummary: "OpenRouter Provisioning Key Required",
+			expectedDetail:  "instead of an inference key through the api_key provider argument, the selected profile, the credential_process command",
+		},
+		"moderation": {
+			err:             &client.APIError{StatusCode: http.StatusForbidden, Message: "Input flagged", Reasons: []string{"harassment"}},
+			expectedSummary: "Client Error",
+			expectedDetail:  "moderation reasons: harassment",
+		},
+		"wrapped": {
+			err:             fmt.Errorf("listing keys: %w", &client.APIError{StatusCode: http.StatusUnauthorized, Message: "Invalid key"}),
+			expectedSummary: "Invalid OpenRouter API Key",
+			expectedDetail:  "Invalid key",
+		},
+		"server error": {
+			err:             &client.APIError{StatusCode: http.StatusInternalServerError, Message: "Internal error"},
+			expectedSummary: "Client Error",
+			expectedDetail:  "Unable to read API key, got error: API error (status 500): Internal error",
+		},
+		"not an API error": {
+			err:             errors.New("connection refused"),
+			expectedSummary: "Client Error",
+			expectedDetail:  "Unable to read API key, got error: connection refused",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(&diags, "Unable to read API key", testCase.err)
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got %v", diags)
+			}
+			if summary := diags[0].Summary(); summary != testCase.expectedSummary {
+				t.Errorf("expected summary %q, got %q", testCase.expectedSummary, summary)
+			}
+			if detail := diags[0].Detail(); !strings.Contains(detail, testCase.expectedDetail) {
+				t.Errorf("expected detail to contain %q, got %q", testCase.expectedDetail, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultEndpoint = "https://openrouter.ai/api/v1"
+	defaultProfile  = "default"
+)
+
+// credentialsPrecedence is included in Configure diagnostics so that users can
+// tell where each value is expected to come from.
+const credentialsPrecedence = "The API key is taken from, in order: the api_key provider attribute, " +
+	"the profile selected with the profile attribute or OPENROUTER_PROFILE environment variable, " +
+	"the OPENROUTER_API_KEY environment variable, and finally the \"default\" profile. " +
+	"The endpoint is taken from the endpoint attribute, then the selected profile, then the default endpoint. " +
+	"Profiles are read from ~/.config/openrouter/credentials, or the file set with OPENROUTER_CREDENTIALS_FILE. " +
+	"When credential_process is set, the API key is always obtained from it."
+
+type credentialsProfile struct {
+	ApiKey   string
+	Endpoint string
+}
+
+type credentialsConfig struct {
+	ApiKey   string
+	Endpoint string
+	Profile  string
+}
+
+type resolvedCredentials struct {
+	ApiKey         string
+	ApiKeySource   string
+	Endpoint       string
+	EndpointSource string
+}
+
+// resolveCredentials applies credentialsPrecedence to the provider
+// configuration, environment and credentials file.
+func resolveCredentials(config credentialsConfig, getenv func(string) string) (resolvedCredentials, error) {
+	resolved := resolvedCredentials{
+		Endpoint:       defaultEndpoint,
+		EndpointSource: "default",
+	}
+
+	profileName := config.Profile
+	profileSource := "profile attribute"
+	if profileName == "" && getenv("OPENROUTER_PROFILE") != "" {
+		profileName = getenv("OPENROUTER_PROFILE")
+		profileSource = "OPENROUTER_PROFILE environment variable"
+	}
+
+	var profile *credentialsProfile
+	if profileName != "" {
+		profiles, path, err := loadCredentialsProfiles(getenv)
+		if err != nil {
+			return resolved, fmt.Errorf("profile %q is set by the %s but the credentials file could not be read: %w", profileName, profileSource, err)
+		}
+		p, ok := profiles[profileName]
+		if !ok {
+			return resolved, fmt.Errorf("profile %q is set by the %s but was not found in %s", profileName, profileSource, path)
+		}
+		profile = &p
+	}
+
+	switch {
+	case config.ApiKey != "":
+		resolved.ApiKey = config.ApiKey
+		resolved.ApiKeySource = "api_key attribute"
+	case profile != nil && profile.ApiKey != "":
+		resolved.ApiKey = profile.ApiKey
+		resolved.ApiKeySource = fmt.Sprintf("profile %q", profileName)
+	case getenv("OPENROUTER_API_KEY") != "":
+		resolved.ApiKey = getenv("OPENROUTER_API_KEY")
+		resolved.ApiKeySource = "OPENROUTER_API_KEY environment variable"
+	case profile == nil:
+		profiles, _, err := loadCredentialsProfiles(getenv)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return resolved, fmt.Errorf("unable to read the credentials file: %w", err)
+		}
+		if p, ok := profiles[defaultProfile]; ok && p.ApiKey != "" {
+			profileName = defaultProfile
+			profile = &p
+			resolved.ApiKey = p.ApiKey
+			resolved.ApiKeySource = fmt.Sprintf("profile %q", defaultProfile)
+		}
+	}
+
+	switch {
+	case config.Endpoint != "":
+		resolved.Endpoint = config.Endpoint
+		resolved.EndpointSource = "endpoint attribute"
+	case profile != nil && profile.Endpoint != "":
+		resolved.Endpoint = profile.Endpoint
+		resolved.EndpointSource = fmt.Sprintf("profile %q", profileName)
+	}
+
+	return resolved, nil
+}
+
+func credentialsFilePath(getenv func(string) string) (string, error) {
+	if path := getenv("OPENROUTER_CREDENTIALS_FILE"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "openrouter", "credentials"), nil
+}
+
+func loadCredentialsProfiles(getenv func(string) string) (map[string]credentialsProfile, string, error) {
+	path, err := credentialsFilePath(getenv)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, path, err
+	}
+
+	profiles, err := parseCredentialsFile(content)
+	if err != nil {
+		return nil, path, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return profiles, path, nil
+}
+
+// parseCredentialsFile parses the flat subset of INI and TOML used by the
+// credentials file: [profile] sections of api_key and endpoint values, quoted
+// or not, with # and ; comments.
+func parseCredentialsFile(content []byte) (map[string]credentialsProfile, error) {
+	profiles := make(map[string]credentialsProfile)
+
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header", lineNumber)
+			}
+			section = unquoteCredentialsValue(strings.TrimSpace(line[1 : len(line)-1]))
+			section = strings.TrimPrefix(section, "profile ")
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			if _, ok := profiles[section]; !ok {
+				profiles[section] = credentialsProfile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: value outside of a profile section", lineNumber)
+		}
+
+		profile := profiles[section]
+		switch strings.TrimSpace(key) {
+		case "api_key":
+			profile.ApiKey = unquoteCredentialsValue(strings.TrimSpace(value))
+		case "endpoint":
+			profile.Endpoint = unquoteCredentialsValue(strings.TrimSpace(value))
+		}
+		profiles[section] = profile
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func unquoteCredentialsValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value
+}
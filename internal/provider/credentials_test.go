@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCredentialsFile = `
+# OpenRouter credentials
+[default]
+api_key = sk-or-v1-default
+
+[staging]
+api_key = "sk-or-v1-staging"
+endpoint = "https://staging.example.com/api/v1"
+
+[profile production]
+api_key = 'sk-or-v1-production' # provisioning key
+`
+
+func testGetenv(t *testing.T, env map[string]string) func(string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(testCredentialsFile), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	return func(key string) string {
+		if key == "OPENROUTER_CREDENTIALS_FILE" {
+			if v, ok := env[key]; ok {
+				return v
+			}
+			return path
+		}
+		return env[key]
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		config               credentialsConfig
+		env                  map[string]string
+		expectedApiKey       string
+		expectedApiKeySource string
+		expectedEndpoint     string
+		expectError          bool
+	}{
+		"api-key-attribute": {
+			config:               credentialsConfig{ApiKey: "sk-or-v1-config"},
+			env:                  map[string]string{"OPENROUTER_API_KEY": "sk-or-v1-env", "OPENROUTER_PROFILE": "staging"},
+			expectedApiKey:       "sk-or-v1-config",
+			expectedApiKeySource: "api_key attribute",
+			expectedEndpoint:     "https://staging.example.com/api/v1",
+		},
+		"profile-attribute": {
+			config:               credentialsConfig{Profile: "staging"},
+			env:                  map[string]string{"OPENROUTER_API_KEY": "sk-or-v1-env", "OPENROUTER_PROFILE": "production"},
+			expectedApiKey:       "sk-or-v1-staging",
+			expectedApiKeySource: `profile "staging"`,
+			expectedEndpoint:     "https://staging.example.com/api/v1",
+		},
+		"profile-environment-variable": {
+			env:                  map[string]string{"OPENROUTER_API_KEY": "sk-or-v1-env", "OPENROUTER_PROFILE": "production"},
+			expectedApiKey:       "sk-or-v1-production",
+			expectedApiKeySource: `profile "production"`,
+			expectedEndpoint:     defaultEndpoint,
+		},
+		"endpoint-attribute-overrides-profile": {
+			config:               credentialsConfig{Profile: "staging", Endpoint: "https://proxy.example.com/api/v1"},
+			expectedApiKey:       "sk-or-v1-staging",
+			expectedApiKeySource: `profile "staging"`,
+			expectedEndpoint:     "https://proxy.example.com/api/v1",
+		},
+		"api-key-environment-variable": {
+			env:                  map[string]string{"OPENROUTER_API_KEY": "sk-or-v1-env"},
+			expectedApiKey:       "sk-or-v1-env",
+			expectedApiKeySource: "OPENROUTER_API_KEY environment variable",
+			expectedEndpoint:     defaultEndpoint,
+		},
+		"default-profile": {
+			expectedApiKey:       "sk-or-v1-default",
+			expectedApiKeySource: `profile "default"`,
+			expectedEndpoint:     defaultEndpoint,
+		},
+		"no-credentials-file": {
+			env:              map[string]string{"OPENROUTER_CREDENTIALS_FILE": filepath.Join(os.TempDir(), "does-not-exist", "credentials")},
+			expectedApiKey:   "",
+			expectedEndpoint: defaultEndpoint,
+		},
+		"unknown-profile": {
+			config:      credentialsConfig{Profile: "missing"},
+			expectError: true,
+		},
+		"profile-without-credentials-file": {
+			config:      credentialsConfig{Profile: "staging"},
+			env:         map[string]string{"OPENROUTER_CREDENTIALS_FILE": filepath.Join(os.TempDir(), "does-not-exist", "credentials")},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveCredentials(tc.config, testGetenv(t, tc.env))
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.ApiKey != tc.expectedApiKey {
+				t.Errorf("expected api key %q, got %q", tc.expectedApiKey, got.ApiKey)
+			}
+
+			if got.ApiKeySource != tc.expectedApiKeySource {
+				t.Errorf("expected api key source %q, got %q", tc.expectedApiKeySource, got.ApiKeySource)
+			}
+
+			if got.Endpoint != tc.expectedEndpoint {
+				t.Errorf("expected endpoint %q, got %q", tc.expectedEndpoint, got.Endpoint)
+			}
+		})
+	}
+}
+
+func TestParseCredentialsFileErrors(t *testing.T) {
+	testCases := map[string]string{
+		"value-outside-section": "api_key = sk-or-v1-test\n",
+		"invalid-section":       "[default\napi_key = sk-or-v1-test\n",
+		"empty-section":         "[]\n",
+		"missing-separator":     "[default]\napi_key\n",
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseCredentialsFile([]byte(content)); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &EstimateCostFunction{}
+
+func NewEstimateCostFunction() function.Function {
+	return &EstimateCostFunction{}
+}
+
+type EstimateCostFunction struct{}
+
+type ModelPricingModel struct {
+	Prompt     types.String `tfsdk:"prompt"`
+	Completion types.String `tfsdk:"completion"`
+}
+
+func (f *EstimateCostFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "estimate_cost"
+}
+
+func (f *EstimateCostFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Estimate the cost of a request in USD.",
+		MarkdownDescription: "Estimates the cost in USD of the given number of prompt and completion tokens using an OpenRouter model `pricing` object, " +
+			"where `prompt` and `completion` are the per-token prices as returned by the OpenRouter models API. " +
+			"Fails for models with variable pricing, such as `openrouter/auto`, whose prices are returned as `-1`.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "pricing",
+				MarkdownDescription: "The model pricing, with `prompt` and `completion` per-token prices in USD.",
+				AttributeTypes: map[string]attr.Type{
+					"prompt":     types.StringType,
+					"completion": types.StringType,
+				},
+			},
+			function.Int64Parameter{
+				Name:                "prompt_tokens",
+				MarkdownDescription: "The number of prompt tokens.",
+			},
+			function.Int64Parameter{
+				Name:                "completion_tokens",
+				MarkdownDescription: "The number of completion tokens.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *EstimateCostFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pricing ModelPricingModel
+	var promptTokens, completionTokens int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &pricing, &promptTokens, &completionTokens))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if promptTokens < 0 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, "prompt_tokens must not be negative"))
+	}
+
+	if completionTokens < 0 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(2, "completion_tokens must not be negative"))
+	}
+
+	promptPrice, err := parsePrice(pricing.Prompt)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("invalid prompt price: %s", err)))
+	}
+
+	completionPrice, err := parsePrice(pricing.Completion)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("invalid completion price: %s", err)))
+	}
+
+	if resp.Error != nil {
+		return
+	}
+
+	cost := promptPrice*float64(promptTokens) + completionPrice*float64(completionTokens)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cost))
+}
+
+// variablePrice is the price OpenRouter returns for models without a fixed
+// price.
+const variablePrice = "-1"
+
+func parsePrice(v types.String) (float64, error) {
+	if v.IsNull() || v.ValueString() == "" {
+		return 0, nil
+	}
+
+	price, err := strconv.ParseFloat(v.ValueString(), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	// OpenRouter reports "-1" for models whose price depends on the model
+	// they route to, such as openrouter/auto.
+	if v.ValueString() == variablePrice {
+		return 0, fmt.Errorf("the model has variable pricing, so its cost cannot be estimated")
+	}
+
+	if price < 0 {
+		return 0, fmt.Errorf("price must not be negative, got %s", v.ValueString())
+	}
+
+	return price, nil
+}
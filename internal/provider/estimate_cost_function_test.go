@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func pricingValue(prompt, completion attr.Value) types.Object {
+	return types.ObjectValueMust(
+		map[string]attr.Type{
+			"prompt":     types.StringType,
+			"completion": types.StringType,
+		},
+		map[string]attr.Value{
+			"prompt":     prompt,
+			"completion": completion,
+		},
+	)
+}
+
+func TestEstimateCostFunction(t *testing.T) {
+	testCases := map[string]struct {
+		pricing          types.Object
+		promptTokens     int64
+		completionTokens int64
+		expected         float64
+		expectError      bool
+		expectErrorText  string
+	}{
+		"prompt-and-completion": {
+			pricing:          pricingValue(types.StringValue("0.000003"), types.StringValue("0.000015")),
+			promptTokens:     1000000,
+			completionTokens: 200000,
+			expected:         6,
+		},
+		"free-model": {
+			pricing:          pricingValue(types.StringValue("0"), types.StringValue("0")),
+			promptTokens:     1000,
+			completionTokens: 1000,
+			expected:         0,
+		},
+		"null-completion-price": {
+			pricing:          pricingValue(types.StringValue("0.000001"), types.StringNull()),
+			promptTokens:     1000,
+			completionTokens: 1000,
+			expected:         0.001,
+		},
+		"invalid-price": {
+			pricing:          pricingValue(types.StringValue("abc"), types.StringValue("0")),
+			promptTokens:     1,
+			completionTokens: 1,
+			expectError:      true,
+		},
+		"negative-price": {
+			pricing:          pricingValue(types.StringValue("-0.5"), types.StringValue("0")),
+			promptTokens:     1,
+			completionTokens: 1,
+			expectError:      true,
+		},
+		"variable-price": {
+			pricing:          pricingValue(types.StringValue("-1"), types.StringValue("-1")),
+			promptTokens:     1,
+			completionTokens: 1,
+			expectError:      true,
+			expectErrorText:  "variable pricing",
+		},
+		"negative-tokens": {
+			pricing:          pricingValue(types.StringValue("0.000001"), types.StringValue("0.000001")),
+			promptTokens:     -1,
+			completionTokens: 1,
+			expectError:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					tc.pricing,
+					types.Int64Value(tc.promptTokens),
+					types.Int64Value(tc.completionTokens),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.Float64Unknown()),
+			}
+
+			NewEstimateCostFunction().Run(context.Background(), req, &resp)
+
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got result %s", resp.Result.Value())
+				}
+				if !strings.Contains(resp.Error.Error(), tc.expectErrorText) {
+					t.Errorf("expected error to contain %q, got %s", tc.expectErrorText, resp.Error)
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got, ok := resp.Result.Value().(types.Float64)
+			if !ok {
+				t.Fatalf("expected types.Float64 result, got %T", resp.Result.Value())
+			}
+
+			if math.Abs(got.ValueFloat64()-tc.expected) > 1e-9 {
+				t.Errorf("expected %v, got %v", tc.expected, got.ValueFloat64())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+const keySuffixLength = 3
+
+var _ function.Function = &KeySuffixFunction{}
+
+func NewKeySuffixFunction() function.Function {
+	return &KeySuffixFunction{}
+}
+
+type KeySuffixFunction struct{}
+
+func (f *KeySuffixFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "key_suffix"
+}
+
+func (f *KeySuffixFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the trailing characters of an API key.",
+		MarkdownDescription: "Returns the last characters of an OpenRouter API key, matching the suffix shown in the key label on the OpenRouter dashboard. " +
+			"Useful for identifying a key in outputs without exposing its value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "The OpenRouter API key value.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *KeySuffixFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &key))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(key) <= keySuffixLength {
+		resp.Error = function.NewArgumentFuncError(0, "key is too short to extract a suffix")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, key[len(key)-keySuffixLength:]))
+}
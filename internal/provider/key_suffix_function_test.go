@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKeySuffixFunction(t *testing.T) {
+	testCases := map[string]struct {
+		key         string
+		expected    string
+		expectError bool
+	}{
+		"provisioned-key": {
+			key:      "sk-or-v1-0123456789abcdef",
+			expected: "def",
+		},
+		"too-short": {
+			key:         "abc",
+			expectError: true,
+		},
+		"empty": {
+			key:         "",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.key)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewKeySuffixFunction().Run(context.Background(), req, &resp)
+
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got result %s", resp.Result.Value())
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if !resp.Result.Value().Equal(types.StringValue(tc.expected)) {
+				t.Errorf("expected %q, got %s", tc.expected, resp.Result.Value())
+			}
+		})
+	}
+}
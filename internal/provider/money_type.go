@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+var (
+	_ basetypes.NumberTypable                    = MoneyType{}
+	_ basetypes.NumberValuableWithSemanticEquals = MoneyValue{}
+)
+
+// moneyPlaces is the number of decimal places compared by the semantic
+// equality of MoneyValue. Amounts that only differ beyond it, such as 0.3 and
+// 0.30000000000000004, are the same amount written with float noise.
+const moneyPlaces = 9
+
+// moneyPrecision is the precision of big.Float values created from amounts,
+// matching the precision Terraform uses for numbers.
+const moneyPrecision = 512
+
+// MoneyType is a number attribute type for amounts in USD. Its values are
+// stored as Terraform numbers, so it can replace a Float64 or Number attribute
+// without a state upgrade.
+type MoneyType struct {
+	basetypes.NumberType
+}
+
+func (t MoneyType) Equal(o attr.Type) bool {
+	other, ok := o.(MoneyType)
+	if !ok {
+		return false
+	}
+
+	return t.NumberType.Equal(other.NumberType)
+}
+
+func (t MoneyType) String() string {
+	return "MoneyType"
+}
+
+func (t MoneyType) ValueFromNumber(ctx context.Context, in basetypes.NumberValue) (basetypes.NumberValuable, diag.Diagnostics) {
+	return MoneyValue{NumberValue: in}, nil
+}
+
+func (t MoneyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.NumberType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	numberValue, ok := attrValue.(basetypes.NumberValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	moneyValue, diags := t.ValueFromNumber(ctx, numberValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting NumberValue to MoneyValue: %v", diags)
+	}
+
+	return moneyValue, nil
+}
+
+func (t MoneyType) ValueType(ctx context.Context) attr.Value {
+	return MoneyValue{}
+}
+
+// MoneyValue is a value of MoneyType.
+type MoneyValue struct {
+	basetypes.NumberValue
+}
+
+func NewMoneyNull() MoneyValue {
+	return MoneyValue{NumberValue: basetypes.NewNumberNull()}
+}
+
+func NewMoneyUnknown() MoneyValue {
+	return MoneyValue{NumberValue: basetypes.NewNumberUnknown()}
+}
+
+func NewMoneyValue(amount client.Money) MoneyValue {
+	value, _, err := big.ParseFloat(amount.String(), 10, moneyPrecision, big.ToNearestEven)
+	if err != nil {
+		// Money always formats as a plain decimal number.
+		panic(fmt.Sprintf("unable to convert amount %s to a number: %s", amount, err))
+	}
+
+	return MoneyValue{NumberValue: basetypes.NewNumberValue(value)}
+}
+
+// NewMoneyPointerValue returns a null value for a nil amount.
+func NewMoneyPointerValue(amount *client.Money) MoneyValue {
+	if amount == nil {
+		return NewMoneyNull()
+	}
+
+	return NewMoneyValue(*amount)
+}
+
+func (v MoneyValue) Equal(o attr.Value) bool {
+	other, ok := o.(MoneyValue)
+	if !ok {
+		return false
+	}
+
+	return v.NumberValue.Equal(other.NumberValue)
+}
+
+func (v MoneyValue) Type(ctx context.Context) attr.Type {
+	return MoneyType{}
+}
+
+// NumberSemanticEquals reports whether both values are the same amount once
+// rounded to moneyPlaces decimal places, so that the amounts returned by the
+// API never cause a difference with the configuration.
+func (v MoneyValue) NumberSemanticEquals(ctx context.Context, newValuable basetypes.NumberValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(MoneyValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	if v.IsNull() || v.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return v.NumberValue.Equal(newValue.NumberValue), diags
+	}
+
+	prior, err := v.money()
+	if err != nil {
+		return false, diags
+	}
+	current, err := newValue.money()
+	if err != nil {
+		return false, diags
+	}
+
+	return prior.Round(moneyPlaces).Equal(current.Round(moneyPlaces)), diags
+}
+
+// ValueMoney returns the amount of a known value, and nil for a null or
+// unknown value.
+func (v MoneyValue) ValueMoney() (*client.Money, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if v.IsNull() || v.IsUnknown() {
+		return nil, diags
+	}
+
+	amount, err := v.money()
+	if err != nil {
+		diags.AddError(
+			"Invalid Amount",
+			fmt.Sprintf("Unable to convert %s to an amount in USD: %s", v.ValueBigFloat().Text('g', 10), err),
+		)
+		return nil, diags
+	}
+
+	return &amount, diags
+}
+
+func (v MoneyValue) money() (client.Money, error) {
+	return client.NewMoney(v.ValueBigFloat().Text('f', -1))
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+func TestMoneyValueNumberSemanticEquals(t *testing.T) {
+	sum := 0.1
+	sum += 0.2
+
+	testCases := map[string]struct {
+		prior    MoneyValue
+		current  basetypes.NumberValuable
+		expected bool
+	}{
+		"equal": {
+			prior:    NewMoneyValue(client.MoneyFromFloat(10)),
+			current:  NewMoneyValue(client.MoneyFromFloat(10)),
+			expected: true,
+		},
+		"trailing zeros": {
+			prior:    moneyFromText(t, "10"),
+			current:  moneyFromText(t, "10.000"),
+			expected: true,
+		},
+		"float noise": {
+			prior:    moneyFromText(t, "0.3"),
+			current:  NewMoneyValue(client.MoneyFromFloat(sum)),
+			expected: true,
+		},
+		"binary float from configuration": {
+			prior:    MoneyValue{NumberValue: basetypes.NewNumberValue(big.NewFloat(0.1))},
+			current:  moneyFromText(t, "0.1"),
+			expected: true,
+		},
+		"cents": {
+			prior:    moneyFromText(t, "10.01"),
+			current:  moneyFromText(t, "10"),
+			expected: false,
+		},
+		"zero and null": {
+			prior:    moneyFromText(t, "0"),
+			current:  NewMoneyNull(),
+			expected: false,
+		},
+		"null": {
+			prior:    NewMoneyNull(),
+			current:  NewMoneyNull(),
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := testCase.prior.NumberSemanticEquals(context.Background(), testCase.current)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, equal)
+			}
+		})
+	}
+}
+
+func TestMoneyValueValueMoney(t *testing.T) {
+	amount, diags := moneyFromText(t, "12.345").ValueMoney()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if amount == nil || amount.String() != "12.345" {
+		t.Errorf("expected 12.345, got %v", amount)
+	}
+
+	amount, diags = NewMoneyNull().ValueMoney()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if amount != nil {
+		t.Errorf("expected nil amount for a null value, got %s", amount)
+	}
+}
+
+func moneyFromText(t *testing.T, text string) MoneyValue {
+	t.Helper()
+
+	amount, err := client.NewMoney(text)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return NewMoneyValue(amount)
+}
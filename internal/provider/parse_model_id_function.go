@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseModelIDFunction{}
+
+func NewParseModelIDFunction() function.Function {
+	return &ParseModelIDFunction{}
+}
+
+type ParseModelIDFunction struct{}
+
+type ModelIDModel struct {
+	Author  types.String `tfsdk:"author"`
+	Slug    types.String `tfsdk:"slug"`
+	Variant types.String `tfsdk:"variant"`
+}
+
+var modelIDAttributeTypes = map[string]attr.Type{
+	"author":  types.StringType,
+	"slug":    types.StringType,
+	"variant": types.StringType,
+}
+
+func (f *ParseModelIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_model_id"
+}
+
+func (f *ParseModelIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an OpenRouter model ID.",
+		MarkdownDescription: "Parses an OpenRouter model ID such as `anthropic/claude-3.5-sonnet:beta` into its `author`, `slug` and `variant` parts. " +
+			"`variant` is null when the model ID has no `:variant` suffix.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "model_id",
+				MarkdownDescription: "The OpenRouter model ID.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: modelIDAttributeTypes,
+		},
+	}
+}
+
+func (f *ParseModelIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var modelID string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &modelID))
+
+	if resp.Error != nil {
+		return
+	}
+
+	author, rest, ok := strings.Cut(modelID, "/")
+	if !ok || author == "" || rest == "" {
+		resp.Error = function.NewArgumentFuncError(0, "model_id must be in the format author/slug or author/slug:variant, got: "+modelID)
+		return
+	}
+
+	result := ModelIDModel{
+		Author:  types.StringValue(author),
+		Variant: types.StringNull(),
+	}
+
+	slug, variant, hasVariant := strings.Cut(rest, ":")
+	if slug == "" || (hasVariant && variant == "") {
+		resp.Error = function.NewArgumentFuncError(0, "model_id must be in the format author/slug or author/slug:variant, got: "+modelID)
+		return
+	}
+
+	result.Slug = types.StringValue(slug)
+	if hasVariant {
+		result.Variant = types.StringValue(variant)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseModelIDFunction(t *testing.T) {
+	testCases := map[string]struct {
+		modelID     string
+		expected    types.Object
+		expectError bool
+	}{
+		"with-variant": {
+			modelID: "anthropic/claude-x:beta",
+			expected: types.ObjectValueMust(modelIDAttributeTypes, map[string]attr.Value{
+				"author":  types.StringValue("anthropic"),
+				"slug":    types.StringValue("claude-x"),
+				"variant": types.StringValue("beta"),
+			}),
+		},
+		"without-variant": {
+			modelID: "openai/gpt-4o",
+			expected: types.ObjectValueMust(modelIDAttributeTypes, map[string]attr.Value{
+				"author":  types.StringValue("openai"),
+				"slug":    types.StringValue("gpt-4o"),
+				"variant": types.StringNull(),
+			}),
+		},
+		"slug-with-dots": {
+			modelID: "meta-llama/llama-3.1-8b-instruct:free",
+			expected: types.ObjectValueMust(modelIDAttributeTypes, map[string]attr.Value{
+				"author":  types.StringValue("meta-llama"),
+				"slug":    types.StringValue("llama-3.1-8b-instruct"),
+				"variant": types.StringValue("free"),
+			}),
+		},
+		"missing-author": {
+			modelID:     "claude-x",
+			expectError: true,
+		},
+		"empty-author": {
+			modelID:     "/claude-x",
+			expectError: true,
+		},
+		"empty-slug": {
+			modelID:     "anthropic/:beta",
+			expectError: true,
+		},
+		"empty-variant": {
+			modelID:     "anthropic/claude-x:",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.modelID)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(modelIDAttributeTypes)),
+			}
+
+			NewParseModelIDFunction().Run(context.Background(), req, &resp)
+
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got result %s", resp.Result.Value())
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if !resp.Result.Value().Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -2,27 +2,91 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/standujar/terraform-provider-openrouter/internal/client"
 )
 
 var _ provider.Provider = &OpenRouterProvider{}
+var _ provider.ProviderWithFunctions = &OpenRouterProvider{}
 
 type OpenRouterProvider struct {
 	version string
 }
 
 type OpenRouterProviderModel struct {
-	ApiKey   types.String `tfsdk:"api_key"`
-	Endpoint types.String `tfsdk:"endpoint"`
+	ApiKey                    types.String  `tfsdk:"api_key"`
+	Endpoint                  types.String  `tfsdk:"endpoint"`
+	Profile                   types.String  `tfsdk:"profile"`
+	CredentialProcess         types.String  `tfsdk:"credential_process"`
+	HTTPReferer               types.String  `tfsdk:"http_referer"`
+	XTitle                    types.String  `tfsdk:"x_title"`
+	Headers                   types.Map     `tfsdk:"headers"`
+	RequestTimeout            types.String  `tfsdk:"request_timeout"`
+	ProxyURL                  types.String  `tfsdk:"proxy_url"`
+	CACertPEM                 types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile                types.String  `tfsdk:"ca_cert_file"`
+	ClientCert                types.String  `tfsdk:"client_cert"`
+	ClientKey                 types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify        types.Bool    `tfsdk:"insecure_skip_verify"`
+	RequestsPerSecond         types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests     types.Int64   `tfsdk:"max_concurrent_requests"`
+	CacheApiKeys              types.Bool    `tfsdk:"cache_api_keys"`
+	SkipCredentialsValidation types.Bool    `tfsdk:"skip_credentials_validation"`
+}
+
+// unknownAttributes returns the names of the attributes that are unknown and
+// that configuring the client depends on, so that it must be deferred.
+// Reading them with ValueString and similar would silently treat them as
+// unset.
+func (m OpenRouterProviderModel) unknownAttributes() []string {
+	attributes := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"api_key", m.ApiKey},
+		{"endpoint", m.Endpoint},
+		{"profile", m.Profile},
+		{"credential_process", m.CredentialProcess},
+		{"proxy_url", m.ProxyURL},
+		{"ca_cert_pem", m.CACertPEM},
+		{"ca_cert_file", m.CACertFile},
+		{"client_cert", m.ClientCert},
+		{"client_key", m.ClientKey},
+		{"insecure_skip_verify", m.InsecureSkipVerify},
+		{"http_referer", m.HTTPReferer},
+		{"x_title", m.XTitle},
+		{"headers", m.Headers},
+		{"request_timeout", m.RequestTimeout},
+		{"requests_per_second", m.RequestsPerSecond},
+		{"max_concurrent_requests", m.MaxConcurrentRequests},
+		{"skip_credentials_validation", m.SkipCredentialsValidation},
+	}
+
+	var unknown []string
+	for _, attribute := range attributes {
+		if attribute.value.IsUnknown() {
+			unknown = append(unknown, attribute.name)
+		}
+	}
+
+	return unknown
 }
 
 func New(version string) func() provider.Provider {
@@ -45,16 +109,112 @@ func (p *OpenRouterProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "API Key for OpenRouter. Can also be set via OPENROUTER_API_KEY environment variable.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					apiKeyFormatValidator{},
+				},
 			},
 			"endpoint": schema.StringAttribute{
 				MarkdownDescription: "API endpoint for OpenRouter. Defaults to https://openrouter.ai/api/v1.",
 				Optional:            true,
+				Validators: []validator.String{
+					endpointURLValidator{},
+				},
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile to read `api_key` and `endpoint` from in `~/.config/openrouter/credentials`. " +
+					"Can also be set via OPENROUTER_PROFILE environment variable. The file location can be changed with the OPENROUTER_CREDENTIALS_FILE environment variable.",
+				Optional: true,
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command run to obtain the API key, for example from a password manager CLI. " +
+					"It must print `{\"api_key\": \"...\", \"expires_at\": \"<RFC3339 timestamp>\"}` to stdout, where `expires_at` is optional. " +
+					"The key is cached for the lifetime of the provider and the command is run again once it expires. Conflicts with `api_key`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+				},
+			},
+			"http_referer": schema.StringAttribute{
+				MarkdownDescription: "URL sent as the `HTTP-Referer` header of every request, identifying the app making changes in OpenRouter.",
+				Optional:            true,
+			},
+			"x_title": schema.StringAttribute{
+				MarkdownDescription: "Name sent as the `X-Title` header of every request, identifying the app making changes in OpenRouter.",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Additional HTTP headers sent with every request. Cannot set `Authorization`, `Content-Type` or `User-Agent`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of each individual HTTP request to the OpenRouter API, as a Go duration such as `30s` or `2m`. Defaults to `30s`. " +
+					"The overall duration of an operation is set with the `timeouts` block of each resource and data source.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy used to reach the OpenRouter API, such as `http://proxy.example.com:3128`. " +
+					"Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted in addition to the system certificates, for example the CA of an inspecting proxy. " +
+					"Conflicts with `ca_cert_file`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM encoded CA certificates trusted in addition to the system certificates. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate for mutual TLS. Requires `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip verification of the API server certificate. Only intended for testing. Defaults to `false`.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests per second sent to the OpenRouter API, shared by all resources and data sources of the provider. " +
+					"Defaults to no limit. Requests are paused regardless once the API reports its rate limit as exhausted.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests to the OpenRouter API in flight at the same time, shared by all resources and data sources of the provider. " +
+					"Defaults to no limit.",
+				Optional: true,
+			},
+			"cache_api_keys": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read API keys from a single snapshot of the key list taken once per Terraform run, instead of requesting each key separately. " +
+					"Speeds up refreshing workspaces with many keys. Keys changed by the provider during the run are always read from the API. Defaults to `false`.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip checking the API key with OpenRouter when the provider is configured, for example to plan without network access. " +
+					"The check reports an invalid key and warns when the key is not a provisioning key. Defaults to `false`.",
+				Optional: true,
 			},
 		},
 	}
 }
 
 func (p *OpenRouterProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	ctx = client.MaskLogSecrets(ctx)
+
 	tflog.Info(ctx, "Configuring OpenRouter client")
 
 	var config OpenRouterProviderModel
@@ -64,6 +224,20 @@ func (p *OpenRouterProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	unknownAttributes := config.unknownAttributes()
+
+	if len(unknownAttributes) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Info(ctx, "Deferring OpenRouter client configuration as the provider configuration is unknown")
+
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+
+			return
+		}
+	}
+
 	if config.ApiKey.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
@@ -73,38 +247,253 @@ func (p *OpenRouterProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Unknown OpenRouter API Endpoint",
+			"The provider cannot create the OpenRouter client as there is an unknown configuration value for the OpenRouter API endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or remove it to use the default endpoint.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown OpenRouter Credential Process",
+			"The provider cannot create the OpenRouter client as there is an unknown configuration value for the OpenRouter credential process. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown OpenRouter Profile",
+			"The provider cannot create the OpenRouter client as there is an unknown configuration value for the OpenRouter profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the OPENROUTER_PROFILE environment variable.",
+		)
+	}
+
+	for _, name := range unknownAttributes {
+		switch name {
+		case "api_key", "endpoint", "credential_process", "profile":
+			// Reported above with remediation specific to credentials.
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown OpenRouter Provider Attribute",
+				fmt.Sprintf("The provider cannot create the OpenRouter client as there is an unknown configuration value for %s. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.", name),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	apiKey := os.Getenv("OPENROUTER_API_KEY")
-	endpoint := "https://openrouter.ai/api/v1"
+	credentials, err := resolveCredentials(credentialsConfig{
+		ApiKey:   config.ApiKey.ValueString(),
+		Endpoint: config.Endpoint.ValueString(),
+		Profile:  config.Profile.ValueString(),
+	}, os.Getenv)
+	if err != nil {
+		detail := fmt.Sprintf("The provider cannot create the OpenRouter client: %s. %s", err, credentialsPrecedence)
 
-	if !config.ApiKey.IsNull() {
-		apiKey = config.ApiKey.ValueString()
+		// Only a profile selected with the profile attribute is attributed to
+		// it. The OPENROUTER_PROFILE environment variable and the default
+		// profile are not part of the configuration.
+		if config.Profile.ValueString() != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "Invalid OpenRouter Profile", detail)
+		} else {
+			resp.Diagnostics.AddError("Unable to Read OpenRouter Credentials", detail)
+		}
+		return
 	}
 
-	if !config.Endpoint.IsNull() {
-		endpoint = config.Endpoint.ValueString()
-	}
+	apiKey := credentials.ApiKey
+	endpoint := credentials.Endpoint
+
+	var clientOpts []client.Option
+
+	if config.CredentialProcess.ValueString() != "" {
+		processCredentials := client.NewProcessCredentials(config.CredentialProcess.ValueString())
+
+		// Run the process once up front so that failures are reported against
+		// the attribute rather than on the first API call.
+		processApiKey, err := processCredentials.ApiKey(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_process"),
+				"Unable to Obtain OpenRouter API Key",
+				fmt.Sprintf("The credential process could not provide an API key: %s", err),
+			)
+			return
+		}
 
-	if apiKey == "" {
+		credentials.ApiKeySource = "credential_process attribute"
+		clientOpts = append(clientOpts, client.WithCredentialSource(processCredentials))
+
+		if !isApiKeyFormat(processApiKey) {
+			addApiKeyFormatError(&resp.Diagnostics, credentials.ApiKeySource)
+		}
+	} else if apiKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing OpenRouter API Key",
 			"The provider cannot create the OpenRouter client as there is a missing or empty value for the OpenRouter API key. "+
-				"Set the api_key value in the configuration or use the OPENROUTER_API_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the api_key value in the configuration, select a profile, or use the OPENROUTER_API_KEY environment variable. "+
+				"If either is already set, ensure the value is not empty. "+credentialsPrecedence,
+		)
+	} else if !isApiKeyFormat(apiKey) {
+		// The api_key attribute is validated on its own, but keys from the
+		// environment or a profile are only known here.
+		addApiKeyFormatError(&resp.Diagnostics, credentials.ApiKeySource)
+	}
+
+	// Likewise, the endpoint attribute is validated on its own, but an
+	// endpoint from a profile is only known here.
+	if !isEndpointURL(endpoint) {
+		resp.Diagnostics.AddError(
+			"Invalid OpenRouter API Endpoint",
+			fmt.Sprintf("The endpoint %q from the %s must be an absolute http or https URL without a query or fragment, such as %q.", endpoint, credentials.EndpointSource, defaultEndpoint),
 		)
 	}
 
+	tflog.Debug(ctx, "Resolved OpenRouter credentials", map[string]any{
+		"api_key_source":  credentials.ApiKeySource,
+		"endpoint_source": credentials.EndpointSource,
+	})
+
+	headers := make(map[string]string)
+
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+
+		for name := range headers {
+			switch http.CanonicalHeaderKey(name) {
+			case "Authorization", "Content-Type", "User-Agent":
+				resp.Diagnostics.AddAttributeError(
+					path.Root("headers").AtMapKey(name),
+					"Invalid OpenRouter Header",
+					fmt.Sprintf("The %s header is set by the provider and cannot be overridden.", name),
+				)
+			}
+		}
+	}
+
+	if config.HTTPReferer.ValueString() != "" {
+		headers["HTTP-Referer"] = config.HTTPReferer.ValueString()
+	}
+
+	if config.XTitle.ValueString() != "" {
+		headers["X-Title"] = config.XTitle.ValueString()
+	}
+
+	userAgent := fmt.Sprintf("terraform-provider-openrouter/%s", p.version)
+	if req.TerraformVersion != "" {
+		userAgent += fmt.Sprintf(" terraform/%s", req.TerraformVersion)
+	}
+
+	clientOpts = append(clientOpts, client.WithUserAgent(userAgent), client.WithHeaders(headers))
+
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || requestTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid OpenRouter Request Timeout",
+				fmt.Sprintf("The request_timeout value %q must be a positive Go duration such as \"30s\" or \"2m\".", config.RequestTimeout.ValueString()),
+			)
+		} else {
+			clientOpts = append(clientOpts, client.WithRequestTimeout(requestTimeout))
+		}
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		if config.RequestsPerSecond.ValueFloat64() <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid OpenRouter Requests Per Second",
+				fmt.Sprintf("The requests_per_second value %g must be greater than 0.", config.RequestsPerSecond.ValueFloat64()),
+			)
+		} else {
+			clientOpts = append(clientOpts, client.WithRateLimit(config.RequestsPerSecond.ValueFloat64()))
+		}
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		if config.MaxConcurrentRequests.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid OpenRouter Max Concurrent Requests",
+				fmt.Sprintf("The max_concurrent_requests value %d must be at least 1.", config.MaxConcurrentRequests.ValueInt64()),
+			)
+		} else {
+			clientOpts = append(clientOpts, client.WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())))
+		}
+	}
+
+	if config.CacheApiKeys.ValueBool() {
+		clientOpts = append(clientOpts, client.WithKeyCache())
+	}
+
+	transportConfig := client.TransportConfig{
+		ProxyURL:           config.ProxyURL.ValueString(),
+		CACertPEM:          []byte(config.CACertPEM.ValueString()),
+		ClientCertPEM:      []byte(config.ClientCert.ValueString()),
+		ClientKeyPEM:       []byte(config.ClientKey.ValueString()),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	if config.CACertFile.ValueString() != "" {
+		caCert, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				fmt.Sprintf("Unable to read the CA certificate file %q, got error: %s", config.CACertFile.ValueString(), err),
+			)
+		}
+		transportConfig.CACertPEM = caCert
+	}
+
+	if transportConfig.ProxyURL != "" || len(transportConfig.CACertPEM) > 0 || len(transportConfig.ClientCertPEM) > 0 ||
+		len(transportConfig.ClientKeyPEM) > 0 || transportConfig.InsecureSkipVerify {
+		transport, err := client.NewTransport(transportConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid OpenRouter HTTP Transport Configuration",
+				fmt.Sprintf("Unable to configure the HTTP transport from proxy_url, ca_cert_pem, ca_cert_file, client_cert and client_key, got error: %s", err),
+			)
+		} else {
+			clientOpts = append(clientOpts, client.WithTransport(transport))
+		}
+
+		if transportConfig.InsecureSkipVerify {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("insecure_skip_verify"),
+				"TLS Certificate Verification Disabled",
+				"insecure_skip_verify is enabled, so the OpenRouter API server certificate is not verified. Do not use this outside of testing.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Creating OpenRouter client")
 
-	client := client.NewClient(apiKey, &endpoint)
+	client := client.NewClient(apiKey, &endpoint, clientOpts...)
+
+	if !config.SkipCredentialsValidation.ValueBool() {
+		validateCredentials(ctx, client, credentials.ApiKeySource, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -112,6 +501,52 @@ func (p *OpenRouterProvider) Configure(ctx context.Context, req provider.Configu
 	tflog.Info(ctx, "Configured OpenRouter client", map[string]any{"endpoint": endpoint})
 }
 
+// addApiKeyFormatError reports a resolved API key that does not look like an
+// OpenRouter API key, without including the key.
+func addApiKeyFormatError(diags *diag.Diagnostics, apiKeySource string) {
+	diags.AddError(
+		"Invalid OpenRouter API Key Format",
+		fmt.Sprintf("The API key from the %s is not an OpenRouter API key. OpenRouter API keys start with %q and have no surrounding whitespace. "+
+			"Check that the whole key was copied.", apiKeySource, apiKeyPrefix),
+	)
+}
+
+// validateCredentials checks the API key with OpenRouter, so that a key that
+// cannot manage API keys is reported once when the provider is configured
+// rather than by every resource. Failures to reach OpenRouter are only
+// warnings, as the resources report them again if they persist.
+func validateCredentials(ctx context.Context, api client.API, apiKeySource string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Validating OpenRouter credentials")
+
+	apiKey, err := api.GetCurrentApiKey(ctx)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			diags.AddError(
+				"Invalid OpenRouter API Key",
+				fmt.Sprintf("OpenRouter rejected the API key from the %s, got error: %s. "+
+					"Check that the key exists and is not disabled. Set skip_credentials_validation to true to skip this check.", apiKeySource, err),
+			)
+			return
+		}
+
+		diags.AddWarning(
+			"Unable to Validate OpenRouter API Key",
+			fmt.Sprintf("Unable to check the API key from the %s with OpenRouter, got error: %s. "+
+				"Set skip_credentials_validation to true to skip this check, for example when planning without network access.", apiKeySource, err),
+		)
+		return
+	}
+
+	if !apiKey.IsProvisioner {
+		diags.AddWarning(
+			"OpenRouter API Key Is Not a Provisioning Key",
+			fmt.Sprintf("The API key from the %s is not a provisioning key, so the openrouter_api_key resource and data sources cannot manage or read API keys. "+
+				"Provider functions still work. Create a provisioning key at https://openrouter.ai/settings/provisioning-keys.", apiKeySource),
+		)
+	}
+}
+
 func (p *OpenRouterProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewApiKeyResource,
@@ -123,4 +558,12 @@ func (p *OpenRouterProvider) DataSources(ctx context.Context) []func() datasourc
 		NewApiKeyDataSource,
 		NewApiKeysDataSource,
 	}
-}
\ No newline at end of file
+}
+
+func (p *OpenRouterProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewEstimateCostFunction,
+		NewParseModelIDFunction,
+		NewKeySuffixFunction,
+	}
+}
@@ -0,0 +1,567 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+	"github.com/standujar/terraform-provider-openrouter/internal/fakeopenrouter"
+)
+
+// testAccNamePrefix prefixes the names of keys created by acceptance tests.
+const testAccNamePrefix = "tf-acc-"
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"openrouter": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccLive reports whether acceptance tests run against the real OpenRouter
+// API, using OPENROUTER_API_KEY, instead of the fake server.
+func testAccLive() bool {
+	return os.Getenv("TF_ACC_LIVE") != ""
+}
+
+// testAccBackend is the OpenRouter API an acceptance test runs against.
+type testAccBackend struct {
+	server *fakeopenrouter.Server
+}
+
+func newTestAccBackend(t *testing.T) *testAccBackend {
+	t.Helper()
+
+	if testAccLive() {
+		return &testAccBackend{}
+	}
+
+	server := fakeopenrouter.NewServer()
+	t.Cleanup(server.Close)
+
+	return &testAccBackend{server: server}
+}
+
+func (b *testAccBackend) preCheck(t *testing.T) {
+	if b.server == nil && os.Getenv("OPENROUTER_API_KEY") == "" {
+		t.Fatal("OPENROUTER_API_KEY must be set for acceptance tests against OpenRouter")
+	}
+}
+
+// config prefixes the configuration with the provider block of the backend.
+func (b *testAccBackend) config(config string) string {
+	if b.server == nil {
+		return config
+	}
+
+	return fmt.Sprintf(`
+provider "openrouter" {
+  api_key  = %q
+  endpoint = %q
+}
+`, fakeopenrouter.ProvisioningKey, b.server.URL+"/api/v1") + config
+}
+
+// client returns a client of the backend, used to check or change keys
+// outside of Terraform.
+func (b *testAccBackend) client() *client.Client {
+	if b.server == nil {
+		return client.NewClient(os.Getenv("OPENROUTER_API_KEY"), nil)
+	}
+
+	endpoint := b.server.URL + "/api/v1"
+	return client.NewClient(fakeopenrouter.ProvisioningKey, &endpoint)
+}
+
+func testAccName() string {
+	return testAccNamePrefix + acctest.RandString(10)
+}
+
+// testAccCheckNoKeyNamed checks that the keys listed by an openrouter_api_keys
+// data source do not include a key with the given name.
+func testAccCheckNoKeyNamed(dataSourceName, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[dataSourceName]
+		if !ok {
+			return fmt.Errorf("data source %s not found in state", dataSourceName)
+		}
+
+		for attribute, value := range ds.Primary.Attributes {
+			if strings.HasPrefix(attribute, "keys.") && strings.HasSuffix(attribute, ".name") && value == name {
+				return fmt.Errorf("expected %s not to list key %q", dataSourceName, name)
+			}
+		}
+		return nil
+	}
+}
+
+func TestProviderConfigureCredentialsErrors(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentialsFile, []byte("[default]\napi_key = sk-or-v1-default\n"), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		profile         string
+		env             map[string]string
+		expectError     string
+		expectAttribute bool
+	}{
+		"profile attribute": {
+			profile:         "missing",
+			expectError:     "Invalid OpenRouter Profile",
+			expectAttribute: true,
+		},
+		"profile environment variable": {
+			env:         map[string]string{"OPENROUTER_PROFILE": "missing"},
+			expectError: "Unable to Read OpenRouter Credentials",
+		},
+		"unreadable credentials file": {
+			env:         map[string]string{"OPENROUTER_CREDENTIALS_FILE": t.TempDir()},
+			expectError: "Unable to Read OpenRouter Credentials",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("OPENROUTER_API_KEY", "")
+			t.Setenv("OPENROUTER_PROFILE", "")
+			t.Setenv("OPENROUTER_CREDENTIALS_FILE", credentialsFile)
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			profile := types.StringNull()
+			if testCase.profile != "" {
+				profile = types.StringValue(testCase.profile)
+			}
+
+			resp := configureTestProvider(t, OpenRouterProviderModel{
+				Profile:                   profile,
+				Headers:                   types.MapNull(types.StringType),
+				SkipCredentialsValidation: types.BoolValue(true),
+			})
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != testCase.expectError {
+				t.Fatalf("expected error %q, got %v", testCase.expectError, resp.Diagnostics)
+			}
+			_, hasPath := errs[0].(diag.DiagnosticWithPath)
+			if hasPath != testCase.expectAttribute {
+				t.Errorf("expected the error to be reported against an attribute: %t, got %t", testCase.expectAttribute, hasPath)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureResolvedEndpoint(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentialsFile, []byte("[default]\napi_key = sk-or-v1-default\nendpoint = https://openrouter.example/api/v1\n\n[schemeless]\napi_key = sk-or-v1-schemeless\nendpoint = openrouter.example/api/v1\n"), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		profile     string
+		expectError bool
+	}{
+		"valid":   {profile: "default"},
+		"invalid": {profile: "schemeless", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("OPENROUTER_API_KEY", "")
+			t.Setenv("OPENROUTER_PROFILE", "")
+			t.Setenv("OPENROUTER_CREDENTIALS_FILE", credentialsFile)
+
+			resp := configureTestProvider(t, OpenRouterProviderModel{
+				Profile:                   types.StringValue(testCase.profile),
+				Headers:                   types.MapNull(types.StringType),
+				SkipCredentialsValidation: types.BoolValue(true),
+			})
+
+			errs := resp.Diagnostics.Errors()
+			if !testCase.expectError {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(errs) != 1 || errs[0].Summary() != "Invalid OpenRouter API Endpoint" {
+				t.Fatalf("expected an invalid endpoint error, got %v", resp.Diagnostics)
+			}
+			if !strings.Contains(errs[0].Detail(), `profile "schemeless"`) {
+				t.Errorf("expected the error to name the profile, got %q", errs[0].Detail())
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureCredentialsValidation(t *testing.T) {
+	server := fakeopenrouter.NewServer()
+	t.Cleanup(server.Close)
+
+	inference := server.AddKey(fakeopenrouter.Key{Name: "inference"})
+
+	testCases := map[string]struct {
+		apiKey          string
+		skip            bool
+		expectError     string
+		expectWarning   string
+		expectRequested bool
+	}{
+		"provisioning key": {
+			apiKey:          fakeopenrouter.ProvisioningKey,
+			expectRequested: true,
+		},
+		"inference key": {
+			apiKey:          inference.Key,
+			expectWarning:   "OpenRouter API Key Is Not a Provisioning Key",
+			expectRequested: true,
+		},
+		"invalid key": {
+			apiKey:          "sk-or-v1-unknown",
+			expectError:     "Invalid OpenRouter API Key",
+			expectRequested: true,
+		},
+		"skipped": {
+			apiKey: "sk-or-v1-unknown",
+			skip:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := len(server.Requests())
+
+			resp := configureTestProvider(t, OpenRouterProviderModel{
+				ApiKey:                    types.StringValue(testCase.apiKey),
+				Endpoint:                  types.StringValue(server.URL + "/api/v1"),
+				Headers:                   types.MapNull(types.StringType),
+				SkipCredentialsValidation: types.BoolValue(testCase.skip),
+			})
+
+			var errors, warnings []string
+			for _, d := range resp.Diagnostics.Errors() {
+				errors = append(errors, d.Summary())
+			}
+			for _, d := range resp.Diagnostics.Warnings() {
+				warnings = append(warnings, d.Summary())
+			}
+
+			if testCase.expectError == "" && len(errors) > 0 || testCase.expectError != "" && (len(errors) != 1 || errors[0] != testCase.expectError) {
+				t.Errorf("expected error %q, got %v", testCase.expectError, errors)
+			}
+			if testCase.expectWarning == "" && len(warnings) > 0 || testCase.expectWarning != "" && (len(warnings) != 1 || warnings[0] != testCase.expectWarning) {
+				t.Errorf("expected warning %q, got %v", testCase.expectWarning, warnings)
+			}
+			if requested := len(server.Requests()) > requests; requested != testCase.expectRequested {
+				t.Errorf("expected the key to be checked: %t", testCase.expectRequested)
+			}
+			if testCase.expectError == "" && resp.ResourceData == nil {
+				t.Errorf("expected the client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureUnknownDeferral(t *testing.T) {
+	testCases := map[string]struct {
+		config      OpenRouterProviderModel
+		expectError string
+	}{
+		"api_key": {
+			config:      OpenRouterProviderModel{ApiKey: types.StringUnknown()},
+			expectError: "Unknown OpenRouter API Key",
+		},
+		"endpoint": {
+			config:      OpenRouterProviderModel{ApiKey: types.StringValue(fakeopenrouter.ProvisioningKey), Endpoint: types.StringUnknown()},
+			expectError: "Unknown OpenRouter API Endpoint",
+		},
+		"profile": {
+			config:      OpenRouterProviderModel{Profile: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Profile",
+		},
+		"credential_process": {
+			config:      OpenRouterProviderModel{CredentialProcess: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Credential Process",
+		},
+		"proxy_url": {
+			config:      OpenRouterProviderModel{ProxyURL: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"ca_cert_pem": {
+			config:      OpenRouterProviderModel{CACertPEM: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"ca_cert_file": {
+			config:      OpenRouterProviderModel{CACertFile: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"client_cert": {
+			config:      OpenRouterProviderModel{ClientCert: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"client_key": {
+			config:      OpenRouterProviderModel{ClientKey: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"insecure_skip_verify": {
+			config:      OpenRouterProviderModel{InsecureSkipVerify: types.BoolUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"http_referer": {
+			config:      OpenRouterProviderModel{HTTPReferer: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"x_title": {
+			config:      OpenRouterProviderModel{XTitle: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"headers": {
+			config:      OpenRouterProviderModel{Headers: types.MapUnknown(types.StringType)},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"request_timeout": {
+			config:      OpenRouterProviderModel{RequestTimeout: types.StringUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"requests_per_second": {
+			config:      OpenRouterProviderModel{RequestsPerSecond: types.Float64Unknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"max_concurrent_requests": {
+			config:      OpenRouterProviderModel{MaxConcurrentRequests: types.Int64Unknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+		"skip_credentials_validation": {
+			config:      OpenRouterProviderModel{SkipCredentialsValidation: types.BoolUnknown()},
+			expectError: "Unknown OpenRouter Provider Attribute",
+		},
+	}
+
+	for name, testCase := range testCases {
+		if testCase.config.Headers.IsNull() {
+			testCase.config.Headers = types.MapNull(types.StringType)
+		}
+
+		t.Run(name+"/deferral allowed", func(t *testing.T) {
+			resp := configureTestProviderWithCapabilities(t, testCase.config, provider.ConfigureProviderClientCapabilities{DeferralAllowed: true})
+
+			if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+				t.Errorf("expected configuration to be deferred, got %v", resp.Deferred)
+			}
+			if len(resp.Diagnostics) > 0 {
+				t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+
+		t.Run(name+"/deferral not allowed", func(t *testing.T) {
+			resp := configureTestProviderWithCapabilities(t, testCase.config, provider.ConfigureProviderClientCapabilities{})
+
+			if resp.Deferred != nil {
+				t.Errorf("expected configuration not to be deferred, got %v", resp.Deferred)
+			}
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != testCase.expectError {
+				t.Fatalf("expected error %q, got %v", testCase.expectError, resp.Diagnostics)
+			}
+			if withPath, ok := errs[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root(name)) {
+				t.Errorf("expected the error to be reported against %s", name)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureHeaders(t *testing.T) {
+	testCases := map[string]struct {
+		header      string
+		expectError bool
+	}{
+		"custom":        {header: "X-Custom"},
+		"authorization": {header: "authorization", expectError: true},
+		"content type":  {header: "Content-Type", expectError: true},
+		"user agent":    {header: "User-Agent", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := configureTestProvider(t, OpenRouterProviderModel{
+				ApiKey:                    types.StringValue(fakeopenrouter.ProvisioningKey),
+				Headers:                   types.MapValueMust(types.StringType, map[string]attr.Value{testCase.header: types.StringValue("value")}),
+				SkipCredentialsValidation: types.BoolValue(true),
+			})
+
+			errs := resp.Diagnostics.Errors()
+			if !testCase.expectError {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(errs) != 1 || errs[0].Summary() != "Invalid OpenRouter Header" {
+				t.Errorf("expected an invalid header error, got %v", resp.Diagnostics)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureRequestTimeout(t *testing.T) {
+	testCases := map[string]struct {
+		requestTimeout string
+		expectError    bool
+	}{
+		"valid":    {requestTimeout: "30s"},
+		"invalid":  {requestTimeout: "thirty seconds", expectError: true},
+		"zero":     {requestTimeout: "0s", expectError: true},
+		"negative": {requestTimeout: "-1m", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := configureTestProvider(t, OpenRouterProviderModel{
+				ApiKey:                    types.StringValue(fakeopenrouter.ProvisioningKey),
+				Headers:                   types.MapNull(types.StringType),
+				RequestTimeout:            types.StringValue(testCase.requestTimeout),
+				SkipCredentialsValidation: types.BoolValue(true),
+			})
+
+			errs := resp.Diagnostics.Errors()
+			if !testCase.expectError {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(errs) != 1 || errs[0].Summary() != "Invalid OpenRouter Request Timeout" {
+				t.Errorf("expected an invalid request timeout error, got %v", resp.Diagnostics)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureResolvedApiKeyFormat(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentialsFile, []byte("[default]\napi_key = sk-or-v1-default\n\n[pasted]\napi_key = or-v1-pasted\n"), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		config      OpenRouterProviderModel
+		env         map[string]string
+		expectError bool
+	}{
+		"environment": {
+			env: map[string]string{"OPENROUTER_API_KEY": fakeopenrouter.ProvisioningKey},
+		},
+		"invalid environment": {
+			env:         map[string]string{"OPENROUTER_API_KEY": " sk-or-v1-pasted"},
+			expectError: true,
+		},
+		"profile": {
+			config: OpenRouterProviderModel{Profile: types.StringValue("default")},
+		},
+		"invalid profile": {
+			config:      OpenRouterProviderModel{Profile: types.StringValue("pasted")},
+			expectError: true,
+		},
+		"credential process": {
+			config: OpenRouterProviderModel{CredentialProcess: types.StringValue(`echo '{"api_key": "sk-or-v1-process"}'`)},
+		},
+		"invalid credential process": {
+			config:      OpenRouterProviderModel{CredentialProcess: types.StringValue(`echo '{"api_key": "Bearer sk-or-v1-process"}'`)},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("OPENROUTER_API_KEY", "")
+			t.Setenv("OPENROUTER_PROFILE", "")
+			t.Setenv("OPENROUTER_CREDENTIALS_FILE", credentialsFile)
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			config := testCase.config
+			config.Headers = types.MapNull(types.StringType)
+			config.SkipCredentialsValidation = types.BoolValue(true)
+
+			resp := configureTestProvider(t, config)
+
+			errs := resp.Diagnostics.Errors()
+			if !testCase.expectError {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(errs) != 1 || errs[0].Summary() != "Invalid OpenRouter API Key Format" {
+				t.Fatalf("expected an invalid API key format error, got %v", resp.Diagnostics)
+			}
+			if strings.Contains(errs[0].Detail(), "or-v1-pasted") || strings.Contains(errs[0].Detail(), "sk-or-v1-process") {
+				t.Errorf("expected the API key to be left out of the error, got %q", errs[0].Detail())
+			}
+		})
+	}
+}
+
+func configureTestProvider(t *testing.T, config OpenRouterProviderModel) *provider.ConfigureResponse {
+	t.Helper()
+
+	return configureTestProviderWithCapabilities(t, config, provider.ConfigureProviderClientCapabilities{})
+}
+
+func configureTestProviderWithCapabilities(t *testing.T, config OpenRouterProviderModel, capabilities provider.ConfigureProviderClientCapabilities) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected config diagnostics: %v", diags)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config:             tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+		ClientCapabilities: capabilities,
+	}, resp)
+	return resp
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/standujar/terraform-provider-openrouter/internal/client"
+)
+
+// Sweepers remove keys leaked by failed acceptance runs against OpenRouter:
+//
+//	OPENROUTER_API_KEY=sk-or-v1-... go test ./internal/provider -v -sweep=all
+//
+// Keys whose name starts with OPENROUTER_SWEEP_PREFIX (default "tf-acc-") and
+// that are older than OPENROUTER_SWEEP_MIN_AGE (default "1h") are deleted, or
+// disabled when OPENROUTER_SWEEP_ACTION is "disable".
+
+const defaultSweepMinAge = time.Hour
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("openrouter_api_key", &resource.Sweeper{
+		Name: "openrouter_api_key",
+		F: func(region string) error {
+			apiKey := os.Getenv("OPENROUTER_API_KEY")
+			if apiKey == "" {
+				return errors.New("OPENROUTER_API_KEY must be set to run sweepers")
+			}
+
+			config, err := sweepConfigFromEnv()
+			if err != nil {
+				return err
+			}
+
+			return sweepApiKeys(context.Background(), client.NewClient(apiKey, nil), config, time.Now())
+		},
+	})
+}
+
+type sweepConfig struct {
+	Prefix  string
+	MinAge  time.Duration
+	Disable bool
+}
+
+func sweepConfigFromEnv() (sweepConfig, error) {
+	config := sweepConfig{
+		Prefix: testAccNamePrefix,
+		MinAge: defaultSweepMinAge,
+	}
+
+	if prefix := os.Getenv("OPENROUTER_SWEEP_PREFIX"); prefix != "" {
+		config.Prefix = prefix
+	}
+
+	if minAge := os.Getenv("OPENROUTER_SWEEP_MIN_AGE"); minAge != "" {
+		duration, err := time.ParseDuration(minAge)
+		if err != nil || duration < 0 {
+			return config, fmt.Errorf("OPENROUTER_SWEEP_MIN_AGE %q must be a non-negative Go duration such as \"1h\"", minAge)
+		}
+		config.MinAge = duration
+	}
+
+	switch action := os.Getenv("OPENROUTER_SWEEP_ACTION"); action {
+	case "", onDestroyDelete:
+	case onDestroyDisable:
+		config.Disable = true
+	default:
+		return config, fmt.Errorf("OPENROUTER_SWEEP_ACTION %q must be %q or %q", action, onDestroyDelete, onDestroyDisable)
+	}
+
+	return config, nil
+}
+
+// sweepApiKeys deletes or disables the keys matching the prefix that were
+// created at least MinAge before now. Keys without a creation time are left
+// alone, as their age is unknown.
+func sweepApiKeys(ctx context.Context, api client.API, config sweepConfig, now time.Time) error {
+	if config.Prefix == "" {
+		return errors.New("refusing to sweep keys without a name prefix")
+	}
+
+	apiKeys, err := api.ListAllApiKeys(ctx, &client.ListApiKeysRequest{IncludeDisabled: true})
+	if err != nil {
+		return fmt.Errorf("unable to list API keys: %w", err)
+	}
+
+	var errs []error
+	for _, apiKey := range apiKeys {
+		if !strings.HasPrefix(apiKey.Name, config.Prefix) || apiKey.CreatedAt == nil || now.Sub(*apiKey.CreatedAt) < config.MinAge {
+			continue
+		}
+
+		if config.Disable {
+			if apiKey.IsDisabled {
+				continue
+			}
+
+			disabled := true
+			_, err = api.UpdateApiKey(ctx, apiKey.ID, &client.UpdateApiKeyRequest{IsDisabled: &disabled})
+		} else {
+			err = api.DeleteApiKey(ctx, apiKey.ID)
+		}
+
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to sweep API key %s (%s): %w", apiKey.Name, apiKey.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestSweepApiKeys(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-2 * time.Hour)
+	recent := now.Add(-10 * time.Minute)
+
+	seed := func(api *client.MemoryClient) {
+		for _, apiKey := range []client.ApiKeyInfo{
+			{ID: "old-test", Name: "tf-acc-old", CreatedAt: &old},
+			{ID: "old-disabled-test", Name: "tf-acc-disabled", CreatedAt: &old, IsDisabled: true},
+			{ID: "recent-test", Name: "tf-acc-recent", CreatedAt: &recent},
+			{ID: "old-production", Name: "production", CreatedAt: &old},
+			{ID: "unknown-age", Name: "tf-acc-unknown"},
+		} {
+			api.Put(apiKey)
+		}
+	}
+
+	testCases := map[string]struct {
+		config           sweepConfig
+		expectedRemoved  []string
+		expectedDisabled []string
+	}{
+		"delete": {
+			config:          sweepConfig{Prefix: "tf-acc-", MinAge: time.Hour},
+			expectedRemoved: []string{"old-test", "old-disabled-test"},
+		},
+		"disable": {
+			config:           sweepConfig{Prefix: "tf-acc-", MinAge: time.Hour, Disable: true},
+			expectedDisabled: []string{"old-test", "old-disabled-test"},
+		},
+		"no minimum age": {
+			config:          sweepConfig{Prefix: "tf-acc-"},
+			expectedRemoved: []string{"old-test", "old-disabled-test", "recent-test"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			api := client.NewMemoryClient()
+			seed(api)
+
+			if err := sweepApiKeys(ctx, api, testCase.config, now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			remaining, err := api.ListAllApiKeys(ctx, &client.ListApiKeysRequest{IncludeDisabled: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			state := make(map[string]bool)
+			for _, apiKey := range remaining {
+				state[apiKey.ID] = apiKey.IsDisabled
+			}
+
+			if len(remaining) != 5-len(testCase.expectedRemoved) {
+				t.Errorf("expected %d keys to remain, got %d", 5-len(testCase.expectedRemoved), len(remaining))
+			}
+			for _, id := range testCase.expectedRemoved {
+				if _, ok := state[id]; ok {
+					t.Errorf("expected %s to be deleted", id)
+				}
+			}
+			for _, id := range testCase.expectedDisabled {
+				if !state[id] {
+					t.Errorf("expected %s to be disabled", id)
+				}
+			}
+			if state["old-production"] {
+				t.Errorf("expected keys without the prefix to be left alone")
+			}
+		})
+	}
+}
+
+func TestSweepApiKeysRequiresPrefix(t *testing.T) {
+	if err := sweepApiKeys(context.Background(), client.NewMemoryClient(), sweepConfig{}, time.Now()); err == nil {
+		t.Error("expected error for an empty prefix")
+	}
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// apiKeyPrefix starts every OpenRouter API key, such as sk-or-v1-0e6...1c96.
+const apiKeyPrefix = "sk-or-"
+
+var (
+	_ validator.String = apiKeyFormatValidator{}
+	_ validator.String = endpointURLValidator{}
+)
+
+// apiKeyFormatValidator checks that a string looks like an OpenRouter API key.
+// Unlike stringvalidator.RegexMatches, its error does not include the value,
+// which is a secret.
+type apiKeyFormatValidator struct{}
+
+func (v apiKeyFormatValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be an OpenRouter API key starting with %q", apiKeyPrefix)
+}
+
+func (v apiKeyFormatValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be an OpenRouter API key starting with `%s`", apiKeyPrefix)
+}
+
+func (v apiKeyFormatValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if isApiKeyFormat(req.ConfigValue.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid OpenRouter API Key Format",
+		fmt.Sprintf("The %s value is not an OpenRouter API key. OpenRouter API keys start with %q and have no surrounding whitespace. "+
+			"Check that the whole key was copied.", req.Path, apiKeyPrefix),
+	)
+}
+
+// isApiKeyFormat reports whether key looks like an OpenRouter API key. It is
+// also used in Configure for keys that do not come from the api_key attribute.
+func isApiKeyFormat(key string) bool {
+	return strings.HasPrefix(key, apiKeyPrefix) && len(key) > len(apiKeyPrefix) && strings.TrimSpace(key) == key
+}
+
+// isEndpointURL reports whether endpoint is an absolute http or https URL
+// without a query or fragment. It is also used in Configure for endpoints that
+// do not come from the endpoint attribute.
+func isEndpointURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.RawQuery == "" && u.Fragment == ""
+}
+
+// endpointURLValidator checks that a string is an absolute http or https URL,
+// such as https://openrouter.ai/api/v1.
+type endpointURLValidator struct{}
+
+func (v endpointURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v endpointURLValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an absolute `http` or `https` URL"
+}
+
+func (v endpointURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	endpoint := req.ConfigValue.ValueString()
+	if isEndpointURL(endpoint) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid OpenRouter API Endpoint",
+		fmt.Sprintf("The %s value %q must be an absolute http or https URL without a query or fragment, such as \"https://openrouter.ai/api/v1\".", req.Path, endpoint),
+	)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApiKeyFormatValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"key":        {value: types.StringValue("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")},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+		"prefix":     {value: types.StringValue("sk-or-"), expectError: true},
+		"other":      {value: types.StringValue("sk-proj-0e6f44a47a05f1dad2ad"), expectError: true},
+		"whitespace": {value: types.StringValue("sk-or-v1-0e6f44a47a05f1dad2ad\n"), expectError: true},
+		"empty":      {value: types.StringValue(""), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			apiKeyFormatValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("api_key"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got %v", testCase.expectError, resp.Diagnostics)
+			}
+			for _, d := range resp.Diagnostics {
+				if len(testCase.value.ValueString()) > len(apiKeyPrefix) && strings.Contains(d.Detail(), testCase.value.ValueString()) {
+					t.Errorf("expected the key not to be included in %q", d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointURLValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"default":  {value: types.StringValue("https://openrouter.ai/api/v1")},
+		"http":     {value: types.StringValue("http://127.0.0.1:8080/api/v1")},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"relative": {value: types.StringValue("openrouter.ai/api/v1"), expectError: true},
+		"scheme":   {value: types.StringValue("ftp://openrouter.ai/api/v1"), expectError: true},
+		"query":    {value: types.StringValue("https://openrouter.ai/api/v1?debug=true"), expectError: true},
+		"invalid":  {value: types.StringValue("https://open router.ai"), expectError: true},
+		"empty":    {value: types.StringValue(""), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			endpointURLValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("endpoint"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
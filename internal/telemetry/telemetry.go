@@ -0,0 +1,138 @@
+// Package telemetry configures OpenTelemetry tracing of provider operations
+// from the environment.
+//
+// Tracing is disabled unless OTEL_TRACES_EXPORTER is set to "otlp", which
+// exports with OTLP over HTTP using the standard OTEL_EXPORTER_OTLP_*
+// variables, or "file", which appends JSON spans to the file set with
+// OPENROUTER_OTEL_TRACES_FILE. Other exporters, which may be set globally for
+// other tools, disable tracing with a warning. A TRACEPARENT variable, as set
+// by CI systems, makes every operation a child of that trace.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	TracerName  = "github.com/standujar/terraform-provider-openrouter"
+	serviceName = "terraform-provider-openrouter"
+)
+
+// parentContext carries the remote span context from TRACEPARENT, if any.
+var parentContext = context.Background()
+
+// Init installs the global tracer provider configured by the environment and
+// returns a function that flushes and stops it.
+func Init(ctx context.Context, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	parentContext = propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	})
+
+	var exporter sdktrace.SpanExporter
+	var closeOutput func() error
+
+	switch os.Getenv("OTEL_TRACES_EXPORTER") {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		otlpExporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create OTLP trace exporter: %w", err)
+		}
+		exporter = otlpExporter
+	case "file":
+		path := os.Getenv("OPENROUTER_OTEL_TRACES_FILE")
+		if path == "" {
+			return nil, errors.New("OPENROUTER_OTEL_TRACES_FILE must be set when OTEL_TRACES_EXPORTER is \"file\"")
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open trace file: %w", err)
+		}
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to create file trace exporter: %w", err)
+		}
+		exporter = fileExporter
+		closeOutput = file.Close
+	default:
+		log.Printf("[WARN] unsupported OTEL_TRACES_EXPORTER %q, expected \"otlp\", \"file\" or \"none\": tracing is disabled", os.Getenv("OTEL_TRACES_EXPORTER"))
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create trace resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return func(ctx context.Context) error {
+		err := tracerProvider.Shutdown(ctx)
+		if closeOutput != nil {
+			err = errors.Join(err, closeOutput())
+		}
+		return err
+	}, nil
+}
+
+// StartOperation starts the span of a provider operation such as a resource
+// create or a data source read. Operations without a parent span become
+// children of the TRACEPARENT trace.
+func StartOperation(ctx context.Context, typeName, operation string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if parent := trace.SpanContextFromContext(parentContext); parent.IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+
+	return otel.Tracer(TracerName).Start(ctx, typeName+"."+operation,
+		trace.WithAttributes(
+			attribute.String("terraform.type_name", typeName),
+			attribute.String("terraform.operation", operation),
+		),
+	)
+}
+
+// EndOperation records the outcome of the operation from its diagnostics and
+// ends the span.
+func EndOperation(span trace.Span, diags diag.Diagnostics) {
+	if diags.HasError() {
+		errs := diags.Errors()
+		span.SetStatus(codes.Error, errs[0].Summary())
+		for _, d := range errs {
+			span.AddEvent("error", trace.WithAttributes(
+				attribute.String("summary", d.Summary()),
+				attribute.String("detail", d.Detail()),
+			))
+		}
+	}
+
+	span.End()
+}
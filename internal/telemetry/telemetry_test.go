@@ -0,0 +1,111 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestStartOperationTraceparent(t *testing.T) {
+	t.Setenv("TRACEPARENT", testTraceparent)
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+
+	if _, err := Init(context.Background(), "test"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	_, span := StartOperation(context.Background(), "openrouter_api_key", "create")
+
+	var diags diag.Diagnostics
+	diags.AddError("Error creating API key", "API error (status 500): boom")
+	EndOperation(span, diags)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	got := spans[0]
+	if got.Name() != "openrouter_api_key.create" {
+		t.Errorf("unexpected span name %q", got.Name())
+	}
+	if got.Parent().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected span to continue the TRACEPARENT trace, got parent %s", got.Parent().TraceID())
+	}
+	if got.Status().Code != codes.Error || got.Status().Description != "Error creating API key" {
+		t.Errorf("unexpected span status %+v", got.Status())
+	}
+}
+
+func TestInitFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("OTEL_TRACES_EXPORTER", "file")
+	t.Setenv("OPENROUTER_OTEL_TRACES_FILE", path)
+
+	shutdown, err := Init(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, span := StartOperation(context.Background(), "data.openrouter_api_keys", "read")
+	EndOperation(span, nil)
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %s", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(contents), "data.openrouter_api_keys.read") {
+		t.Errorf("expected span in trace file, got %s", contents)
+	}
+}
+
+func TestInitUnsupportedExporter(t *testing.T) {
+	for _, exporter := range []string{"console", "zipkin"} {
+		t.Run(exporter, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_EXPORTER", exporter)
+
+			shutdown, err := Init(context.Background(), "test")
+			if err != nil {
+				t.Fatalf("expected tracing to be disabled without error, got: %s", err)
+			}
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("unexpected shutdown error: %s", err)
+			}
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	testCases := map[string]map[string]string{
+		"file without path": {"OTEL_TRACES_EXPORTER": "file", "OPENROUTER_OTEL_TRACES_FILE": ""},
+	}
+
+	for name, env := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			if _, err := Init(context.Background(), "test"); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
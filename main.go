@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/standujar/terraform-provider-openrouter/internal/provider"
+	"github.com/standujar/terraform-provider-openrouter/internal/telemetry"
 )
 
 var (
@@ -24,9 +26,21 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	shutdownTracing, err := telemetry.Init(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Flush pending spans before exiting, as log.Fatal skips deferred calls.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("failed to flush traces: %s", shutdownErr)
+	}
+	cancel()
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-}
\ No newline at end of file
+}